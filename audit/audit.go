@@ -0,0 +1,80 @@
+// Package audit provides audit records of domain mutations.
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+var _ error = (*Error)(nil)
+
+// Operation represents a mutating operation.
+type Operation string
+
+const (
+	OperationAdd    Operation = "add"
+	OperationDelete Operation = "delete"
+)
+
+// Outcome represents the outcome of an operation.
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+)
+
+// Record describes a mutating API call.
+type Record struct {
+	Time      time.Time `json:"time"`
+	Actor     string    `json:"actor,omitempty"`
+	ProjectID string    `json:"project_id,omitempty"`
+	Operation Operation `json:"operation"`
+	Domains   []string  `json:"domains"`
+	Response  any       `json:"response,omitempty"`
+	Outcome   Outcome   `json:"outcome"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Sink receives audit records.
+type Sink interface {
+	Write(ctx context.Context, record *Record) error
+}
+
+// SinkFunc is an adapter to use a function as a Sink.
+type SinkFunc func(ctx context.Context, record *Record) error
+
+// Write calls f(ctx, record).
+func (f SinkFunc) Write(ctx context.Context, record *Record) error {
+	return f(ctx, record)
+}
+
+// Error is returned when a call succeeded but its record could not be written to the sink.
+type Error struct {
+	Record *Record
+	Err    error
+}
+
+// Error returns the error message for the Error.
+func (err *Error) Error() string {
+	return fmt.Sprintf("audit: %s", err.Err)
+}
+
+// Unwrap returns the error of the sink.
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+type actorKey struct{}
+
+// WithActor returns a context that labels audit records with the actor.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor set by WithActor, or empty if not set.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
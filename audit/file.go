@@ -0,0 +1,157 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var (
+	// ErrTampered is returned when the hash chain of a journal is broken.
+	ErrTampered = errors.New("audit journal tampered")
+
+	_ Sink  = (*FileSink)(nil)
+	_ error = (*TamperError)(nil)
+)
+
+// Entry is a line of the journal written by FileSink.
+// Hash is the SHA-256 of PrevHash followed by Record, chaining the entries.
+type Entry struct {
+	Record   json.RawMessage `json:"record"`
+	PrevHash string          `json:"prev_hash"`
+	Hash     string          `json:"hash"`
+}
+
+// FileSink is an append-only JSON Lines journal that is tamper-evident via hash chaining.
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+	hash string
+}
+
+// NewFileSink opens the journal at the path, creating it if it does not exist.
+// The existing entries are verified before appending.
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(filepath.Clean(path), os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := verify(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return &FileSink{
+		file: file,
+		hash: hash,
+	}, nil
+}
+
+// Write appends the record to the journal.
+func (s *FileSink) Write(_ context.Context, record *Record) error {
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := &Entry{
+		Record:   raw,
+		PrevHash: s.hash,
+		Hash:     hash(s.hash, raw),
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	if err := s.file.Sync(); err != nil {
+		return err
+	}
+
+	s.hash = entry.Hash
+	return nil
+}
+
+// Close closes the journal.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
+
+// TamperError reports the first entry of a journal whose hash chain is broken.
+type TamperError struct {
+	Line   int
+	Reason string
+}
+
+// Error returns the error message for the TamperError.
+func (err *TamperError) Error() string {
+	return fmt.Sprintf("%s: line %d: %s", ErrTampered, err.Line, err.Reason)
+}
+
+// Unwrap returns ErrTampered.
+func (err *TamperError) Unwrap() error {
+	return ErrTampered
+}
+
+// Verify checks the hash chain of the journal read from r.
+// A *TamperError is returned when an entry was modified, inserted or removed.
+// Entries removed from the end of the journal cannot be detected.
+func Verify(r io.Reader) error {
+	_, err := verify(r)
+	return err
+}
+
+// verify checks the hash chain and returns the hash of the last entry.
+func verify(r io.Reader) (string, error) {
+	var prev string
+
+	reader := bufio.NewReader(r)
+	for line := 1; ; line++ {
+		raw, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(raw)) > 0 {
+			var entry Entry
+			if err := json.Unmarshal(raw, &entry); err != nil {
+				return "", &TamperError{Line: line, Reason: "malformed entry"}
+			}
+			if entry.PrevHash != prev {
+				return "", &TamperError{Line: line, Reason: "previous hash mismatch"}
+			}
+			if entry.Hash != hash(entry.PrevHash, entry.Record) {
+				return "", &TamperError{Line: line, Reason: "hash mismatch"}
+			}
+			prev = entry.Hash
+		}
+		if errors.Is(err, io.EOF) {
+			return prev, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+func hash(prev string, record []byte) string {
+	h := sha256.New()
+	h.Write([]byte(prev))
+	h.Write(record)
+	return hex.EncodeToString(h.Sum(nil))
+}
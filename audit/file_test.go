@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeRecords(t *testing.T, path string, records ...*Record) {
+	t.Helper()
+
+	sink, err := NewFileSink(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, record := range records {
+		assert.NoError(t, sink.Write(t.Context(), record))
+	}
+	assert.NoError(t, sink.Close())
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	ctx := WithActor(t.Context(), "alice")
+
+	writeRecords(t, path, &Record{
+		Time:      time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+		Actor:     ActorFromContext(ctx),
+		Operation: OperationAdd,
+		Domains:   []string{"example.com"},
+		Outcome:   OutcomeSuccess,
+	})
+	// reopening continues the chain
+	writeRecords(t, path, &Record{
+		Time:      time.Date(2025, 9, 2, 0, 0, 0, 0, time.UTC),
+		Operation: OperationDelete,
+		Domains:   []string{"example.com"},
+		Outcome:   OutcomeFailure,
+		Error:     "api error",
+	})
+
+	raw, err := os.ReadFile(path)
+	assert.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(raw), []byte("\n"))
+	if assert.Len(t, lines, 2) {
+		assert.Contains(t, string(lines[0]), `"actor":"alice"`)
+		assert.Contains(t, string(lines[0]), `"prev_hash":""`)
+	}
+	assert.NoError(t, Verify(bytes.NewReader(raw)))
+}
+
+func TestVerify_tampered(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	writeRecords(t, path,
+		&Record{Operation: OperationAdd, Domains: []string{"example.com"}, Outcome: OutcomeSuccess},
+		&Record{Operation: OperationDelete, Domains: []string{"example.com"}, Outcome: OutcomeSuccess},
+		&Record{Operation: OperationAdd, Domains: []string{"example.net"}, Outcome: OutcomeSuccess},
+	)
+
+	raw, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	lines := bytes.SplitAfter(raw, []byte("\n"))
+
+	tests := []struct {
+		name     string
+		journal  []byte
+		wantLine int
+	}{
+		{
+			"modified",
+			bytes.Replace(raw, []byte(`"delete"`), []byte(`"add"`), 1),
+			2,
+		},
+		{
+			"removed",
+			bytes.Join([][]byte{lines[0], lines[2]}, nil),
+			2,
+		},
+		{
+			"malformed",
+			bytes.Join([][]byte{lines[0], []byte("{\n")}, nil),
+			2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(bytes.NewReader(tt.journal))
+
+			var tamperErr *TamperError
+			if assert.ErrorAs(t, err, &tamperErr) {
+				assert.Equal(t, tt.wantLine, tamperErr.Line)
+			}
+			assert.ErrorIs(t, err, ErrTampered)
+		})
+	}
+}
+
+func TestNewFileSink_tampered(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	err := os.WriteFile(path, []byte(`{"record":{},"prev_hash":"","hash":"invalid"}`+"\n"), 0o600)
+	assert.NoError(t, err)
+
+	sink, err := NewFileSink(path)
+
+	assert.Nil(t, sink)
+	assert.ErrorIs(t, err, ErrTampered)
+}
@@ -33,6 +33,11 @@ func (c *Client) Close() error {
 	return c.resty.Close()
 }
 
+// Options returns the client options merged with the specified options.
+func (c *Client) Options(options ...option.Option) *option.ClientOptions {
+	return c.options.Merge(options...)
+}
+
 // Get performs a GET request to the specified path with query parameters.
 func (c *Client) Get(
 	ctx context.Context,
@@ -0,0 +1,130 @@
+package export
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+type column struct {
+	name  string
+	index []int
+}
+
+type columns []column
+
+// newColumns derives the columns of T from its JSON tags.
+// Types other than structs are exported as a single column with the specified name.
+func newColumns[T any](name string) (columns, error) {
+	t := reflect.TypeFor[T]()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		if name == "" {
+			return nil, nil
+		}
+		return columns{{name: name}}, nil
+	}
+
+	var cs columns
+	appendFields(&cs, t, nil)
+	if len(cs) == 0 {
+		return nil, fmt.Errorf("export: no exported fields in %s", t)
+	}
+	return cs, nil
+}
+
+func appendFields(cs *columns, t reflect.Type, index []int) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		fieldIndex := slices.Concat(index, []int{i})
+
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			appendFields(cs, ft, fieldIndex)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		*cs = append(*cs, column{name: name, index: fieldIndex})
+	}
+}
+
+func (cs columns) names() []string {
+	names := make([]string, len(cs))
+	for i, c := range cs {
+		names[i] = c.name
+	}
+	return names
+}
+
+// pick returns the columns with the specified names in the specified order.
+func (cs columns) pick(names []string) (columns, error) {
+	picked := make(columns, 0, len(names))
+	for _, name := range names {
+		i := -1
+		for j, c := range cs {
+			if c.name == name {
+				i = j
+				break
+			}
+		}
+		if i < 0 {
+			return nil, fmt.Errorf("export: unknown metadata column: %q", name)
+		}
+		picked = append(picked, cs[i])
+	}
+	return picked, nil
+}
+
+// extract returns the values of the columns in v.
+// Missing values, such as fields behind nil pointers, are returned as nil.
+func (cs columns) extract(v any) []any {
+	row := make([]any, len(cs))
+	for i, c := range cs {
+		row[i] = field(reflect.ValueOf(v), c.index)
+	}
+	return row
+}
+
+func field(v reflect.Value, index []int) any {
+	for _, i := range index {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return nil
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
@@ -0,0 +1,163 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+type encoder interface {
+	begin(columns []string) error
+	encode(row []any) error
+	end() error
+}
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func newCSVEncoder(w io.Writer) *csvEncoder {
+	return &csvEncoder{
+		w: csv.NewWriter(w),
+	}
+}
+
+func (e *csvEncoder) begin(columns []string) error {
+	return e.w.Write(columns)
+}
+
+func (e *csvEncoder) encode(row []any) error {
+	record := make([]string, len(row))
+	for i, value := range row {
+		s, err := formatCell(value)
+		if err != nil {
+			return err
+		}
+		record[i] = s
+	}
+	return e.w.Write(record)
+}
+
+func (e *csvEncoder) end() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func formatCell(value any) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	}
+
+	if s, ok := value.(fmt.Stringer); ok {
+		return s.String(), nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+type jsonLinesEncoder struct {
+	w       *bufio.Writer
+	columns []string
+}
+
+func newJSONLinesEncoder(w io.Writer) *jsonLinesEncoder {
+	return &jsonLinesEncoder{
+		w: bufio.NewWriter(w),
+	}
+}
+
+func (e *jsonLinesEncoder) begin(columns []string) error {
+	e.columns = columns
+	return nil
+}
+
+func (e *jsonLinesEncoder) encode(row []any) error {
+	if err := writeObject(e.w, e.columns, row); err != nil {
+		return err
+	}
+	return e.w.WriteByte('\n')
+}
+
+func (e *jsonLinesEncoder) end() error {
+	return e.w.Flush()
+}
+
+type jsonEncoder struct {
+	w       *bufio.Writer
+	columns []string
+	count   int
+}
+
+func newJSONEncoder(w io.Writer) *jsonEncoder {
+	return &jsonEncoder{
+		w: bufio.NewWriter(w),
+	}
+}
+
+func (e *jsonEncoder) begin(columns []string) error {
+	e.columns = columns
+	return e.w.WriteByte('[')
+}
+
+func (e *jsonEncoder) encode(row []any) error {
+	if e.count > 0 {
+		if err := e.w.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	e.count++
+	return writeObject(e.w, e.columns, row)
+}
+
+func (e *jsonEncoder) end() error {
+	if _, err := e.w.WriteString("]\n"); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
+
+// writeObject writes a JSON object keeping the order of the columns.
+func writeObject(w *bufio.Writer, columns []string, row []any) error {
+	if err := w.WriteByte('{'); err != nil {
+		return err
+	}
+	for i, column := range columns {
+		if i > 0 {
+			if err := w.WriteByte(','); err != nil {
+				return err
+			}
+		}
+		key, err := json.Marshal(column)
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(row[i])
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "%s:%s", key, value); err != nil {
+			return err
+		}
+	}
+	return w.WriteByte('}')
+}
@@ -0,0 +1,129 @@
+// Package export provides streaming exporters for paginated results.
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/pager"
+)
+
+// Format represents an output format.
+type Format int
+
+const (
+	// CSV writes a header row followed by one row per item.
+	CSV Format = iota
+	// JSONLines writes one JSON object per line.
+	JSONLines
+	// JSON writes a single JSON array of objects.
+	JSON
+
+	// NDJSON is an alias of JSONLines.
+	NDJSON = JSONLines
+)
+
+type metadata interface {
+	HasNextPage() bool
+	NextPageCursor() *string
+}
+
+// Write streams all items of the pager to w in the specified format.
+func Write[T any, M metadata](
+	ctx context.Context,
+	w io.Writer,
+	format Format,
+	p *pager.Pager[T, M],
+	options ...Option,
+) error {
+	return WriteItems(w, format, p.Iter(ctx), options...)
+}
+
+// WriteItems streams the items to w in the specified format.
+// Metadata columns requested by WithMetadata are taken from each item's metadata.
+func WriteItems[T any, M metadata](
+	w io.Writer,
+	format Format,
+	seq iter.Seq2[*pager.Item[T, M], error],
+	options ...Option,
+) error {
+	o := newOptions(options...)
+
+	values, err := newColumns[T](o.ValueColumn)
+	if err != nil {
+		return err
+	}
+	meta, err := newColumns[M]("")
+	if err != nil {
+		return err
+	}
+	meta, err = meta.pick(o.Metadata)
+	if err != nil {
+		return err
+	}
+
+	e, err := newEncoder(w, format)
+	if err != nil {
+		return err
+	}
+	if err := e.begin(slices.Concat(values.names(), meta.names())); err != nil {
+		return err
+	}
+	for item, err := range seq {
+		if err != nil {
+			return err
+		}
+		row := slices.Concat(values.extract(item.Value), meta.extract(item.Meta))
+		if err := e.encode(row); err != nil {
+			return err
+		}
+	}
+	return e.end()
+}
+
+// WriteValues streams the values to w in the specified format.
+func WriteValues[T any](
+	w io.Writer,
+	format Format,
+	seq iter.Seq2[T, error],
+	options ...Option,
+) error {
+	o := newOptions(options...)
+
+	values, err := newColumns[T](o.ValueColumn)
+	if err != nil {
+		return err
+	}
+
+	e, err := newEncoder(w, format)
+	if err != nil {
+		return err
+	}
+	if err := e.begin(values.names()); err != nil {
+		return err
+	}
+	for value, err := range seq {
+		if err != nil {
+			return err
+		}
+		if err := e.encode(values.extract(value)); err != nil {
+			return err
+		}
+	}
+	return e.end()
+}
+
+func newEncoder(w io.Writer, format Format) (encoder, error) {
+	switch format {
+	case CSV:
+		return newCSVEncoder(w), nil
+	case JSONLines:
+		return newJSONLinesEncoder(w), nil
+	case JSON:
+		return newJSONEncoder(w), nil
+	}
+	return nil, fmt.Errorf("export: unknown format: %d", format)
+}
@@ -0,0 +1,151 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/pager"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/resource/stats"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMock(t *testing.T) {
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv/domains",
+		func(req *http.Request) (*http.Response, error) {
+			meta := stats.DomainsListMetadata{
+				ProjectID: "project",
+				From:      "2025-08",
+				To:        "2025-09",
+			}
+
+			switch req.URL.Query().Get(pager.Cursor) {
+			case "":
+				meta.HasNext = true
+				meta.NextCursor = lo.ToPtr("cursor1")
+				return httpmock.NewJsonResponse(http.StatusOK, pager.Page[*stats.DomainsListResult, *stats.DomainsListMetadata]{
+					Result: []*stats.DomainsListResult{
+						{Domain: "1.example.com", Value: 100},
+						{Domain: "2.example.com", Value: 200},
+					},
+					Meta: &meta,
+				})
+			case "cursor1":
+				return httpmock.NewJsonResponse(http.StatusOK, pager.Page[*stats.DomainsListResult, *stats.DomainsListMetadata]{
+					Result: []*stats.DomainsListResult{
+						{Domain: "3.example.com", Value: 300},
+					},
+					Meta: &meta,
+				})
+			}
+			return nil, errors.New("unreachable")
+		},
+	)
+}
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  Format
+		options []Option
+		want    string
+	}{
+		{
+			"csv",
+			CSV,
+			nil,
+			"domain,value\n" +
+				"1.example.com,100\n" +
+				"2.example.com,200\n" +
+				"3.example.com,300\n",
+		},
+		{
+			"csv with metadata",
+			CSV,
+			[]Option{WithMetadata("project_id", "from", "to")},
+			"domain,value,project_id,from,to\n" +
+				"1.example.com,100,project,2025-08,2025-09\n" +
+				"2.example.com,200,project,2025-08,2025-09\n" +
+				"3.example.com,300,project,2025-08,2025-09\n",
+		},
+		{
+			"json lines",
+			JSONLines,
+			[]Option{WithMetadata("project_id")},
+			`{"domain":"1.example.com","value":100,"project_id":"project"}` + "\n" +
+				`{"domain":"2.example.com","value":200,"project_id":"project"}` + "\n" +
+				`{"domain":"3.example.com","value":300,"project_id":"project"}` + "\n",
+		},
+		{
+			"json",
+			JSON,
+			nil,
+			`[{"domain":"1.example.com","value":100},{"domain":"2.example.com","value":200},{"domain":"3.example.com","value":300}]` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupMock(t)
+
+			c := clienttest.NewClient(t)
+			list := stats.NewDomains(c).List(nil)
+
+			var buf bytes.Buffer
+			err := Write(t.Context(), &buf, tt.format, list, tt.options...)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestWrite_unknownMetadata(t *testing.T) {
+	setupMock(t)
+
+	c := clienttest.NewClient(t)
+	list := stats.NewDomains(c).List(nil)
+
+	var buf bytes.Buffer
+	err := Write(t.Context(), &buf, CSV, list, WithMetadata("unknown"))
+
+	assert.EqualError(t, err, `export: unknown metadata column: "unknown"`)
+	assert.Empty(t, buf.String())
+}
+
+func TestWriteValues(t *testing.T) {
+	seq := func(yield func(string, error) bool) {
+		for _, value := range []string{"example.com", "example.net"} {
+			if !yield(value, nil) {
+				return
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	err := WriteValues(&buf, CSV, seq, WithValueColumn("domain"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "domain\nexample.com\nexample.net\n", buf.String())
+}
+
+func TestWriteValues_error(t *testing.T) {
+	wantErr := errors.New("test error")
+	seq := func(yield func(string, error) bool) {
+		if !yield("example.com", nil) {
+			return
+		}
+		yield("", wantErr)
+	}
+
+	var buf bytes.Buffer
+	err := WriteValues(&buf, JSONLines, seq)
+
+	assert.ErrorIs(t, err, wantErr)
+}
@@ -0,0 +1,39 @@
+package export
+
+// DefaultValueColumn is the column name used for values that are not structs.
+const DefaultValueColumn = "value"
+
+type Option func(*Options)
+
+// WithMetadata adds the metadata fields with the specified JSON names as columns,
+// e.g. "project_id", "from" and "to".
+func WithMetadata(columns ...string) Option {
+	return func(o *Options) {
+		o.Metadata = append(o.Metadata, columns...)
+	}
+}
+
+// WithValueColumn sets the column name used for values that are not structs,
+// such as the domain names yielded by Domains.List.
+//
+// Default: "value"
+func WithValueColumn(name string) Option {
+	return func(o *Options) {
+		o.ValueColumn = name
+	}
+}
+
+type Options struct {
+	Metadata    []string
+	ValueColumn string
+}
+
+func newOptions(options ...Option) *Options {
+	o := &Options{
+		ValueColumn: DefaultValueColumn,
+	}
+	for _, option := range options {
+		option(o)
+	}
+	return o
+}
@@ -0,0 +1,63 @@
+// Package parallel provides bounded concurrency shared by the resources.
+package parallel
+
+import (
+	"context"
+	"sync"
+)
+
+// Each calls f for 0 to n-1 with up to concurrency calls at once and returns the error of each call.
+// The calls not started because ctx is done have the error of ctx.
+func Each(
+	ctx context.Context,
+	n int,
+	concurrency int,
+	f func(ctx context.Context, i int) error,
+) []error {
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, max(concurrency, 1))
+	for i := range n {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = f(ctx, i)
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// ForEach calls f for 0 to n-1 with up to concurrency calls at once.
+// It stops at the first error and returns it.
+func ForEach(
+	ctx context.Context,
+	n int,
+	concurrency int,
+	f func(ctx context.Context, i int) error,
+) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	Each(ctx, n, concurrency, func(ctx context.Context, i int) error {
+		err := f(ctx, i)
+		if err != nil {
+			cancel(err)
+		}
+		return err
+	})
+	return context.Cause(ctx)
+}
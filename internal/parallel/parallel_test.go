@@ -0,0 +1,57 @@
+package parallel
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEach(t *testing.T) {
+	var running, peak atomic.Int32
+	errs := Each(t.Context(), 5, 2, func(_ context.Context, i int) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		if i == 3 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	assert.Equal(t, []error{nil, nil, nil, errors.New("failed"), nil}, errs)
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+func TestEach_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	errs := Each(ctx, 2, 1, func(context.Context, int) error {
+		return nil
+	})
+
+	assert.Equal(t, []error{context.Canceled, context.Canceled}, errs)
+}
+
+func TestForEach(t *testing.T) {
+	errFailed := errors.New("failed")
+	var calls atomic.Int32
+	err := ForEach(t.Context(), 10, 1, func(_ context.Context, i int) error {
+		calls.Add(1)
+		if i == 1 {
+			return errFailed
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, errFailed)
+	assert.Equal(t, int32(2), calls.Load())
+}
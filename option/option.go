@@ -5,12 +5,21 @@ import (
 	"net/http"
 	"net/url"
 	"time"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/audit"
 )
 
 const (
 	DefaultBaseURL = "https://api.morisawafonts.com/webfont/v1"
 	DefaultTimeout = 30 * time.Second
 	DefaultRetry   = 2
+
+	// MaxPageSize is the maximum number of items per page accepted by the API.
+	MaxPageSize = 100
+
+	DefaultChunkSize      = 100
+	DefaultConcurrency    = 1
+	DefaultDomainCacheTTL = time.Minute
 )
 
 type Option func(*ClientOptions)
@@ -53,3 +62,149 @@ func WithRetry(retry int) Option {
 		o.Retry = retry
 	}
 }
+
+// WithMaxPages sets the maximum number of pages a pager fetches.
+// Zero means no limit.
+//
+// Default: 0
+func WithMaxPages(pages int) Option {
+	return func(o *ClientOptions) {
+		o.MaxPages = pages
+	}
+}
+
+// WithMaxItems sets the maximum number of items a pager fetches.
+// Zero means no limit.
+//
+// Default: 0
+func WithMaxItems(items int) Option {
+	return func(o *ClientOptions) {
+		o.MaxItems = items
+	}
+}
+
+// WithPageSize sets the default number of items per page for pagers.
+// It is ignored when the limit is specified in the pager input.
+// Zero means the API default.
+//
+// Default: 0
+func WithPageSize(size int) Option {
+	return func(o *ClientOptions) {
+		o.PageSize = size
+	}
+}
+
+// WithAdaptivePageSize enables halving the page size and retrying
+// when fetching a page times out.
+// When no page size is set, it starts from MaxPageSize.
+//
+// Default: false
+func WithAdaptivePageSize(enabled bool) Option {
+	return func(o *ClientOptions) {
+		o.AdaptivePageSize = enabled
+	}
+}
+
+// WithDomainNormalization enables normalizing and validating domains
+// before they are sent to the API.
+//
+// Default: true
+func WithDomainNormalization(enabled bool) Option {
+	return func(o *ClientOptions) {
+		o.DisableDomainNormalization = !enabled
+	}
+}
+
+// WithChunkSize sets the number of domains sent in a single request
+// when many domains are added or deleted at once.
+//
+// Default: 100
+func WithChunkSize(size int) Option {
+	return func(o *ClientOptions) {
+		o.ChunkSize = size
+	}
+}
+
+// WithMaxDeletions sets the maximum number of domains a reconciliation may delete.
+// Zero means no limit.
+//
+// Default: 0
+func WithMaxDeletions(deletions int) Option {
+	return func(o *ClientOptions) {
+		o.MaxDeletions = deletions
+	}
+}
+
+// WithConcurrency sets the maximum number of requests run concurrently
+// when a large operation is split into multiple requests.
+//
+// Default: 1
+func WithConcurrency(concurrency int) Option {
+	return func(o *ClientOptions) {
+		o.Concurrency = concurrency
+	}
+}
+
+// WithDomainCacheTTL sets how long the registered domains are cached for membership lookups.
+//
+// Default: 1 minute
+func WithDomainCacheTTL(ttl time.Duration) Option {
+	return func(o *ClientOptions) {
+		o.DomainCacheTTL = ttl
+	}
+}
+
+// WithAuditSink sets the sink that records every call adding or deleting domains.
+func WithAuditSink(sink audit.Sink) Option {
+	return func(o *ClientOptions) {
+		o.AuditSink = sink
+	}
+}
+
+// WithProjectID sets the project ID recorded in audit records.
+func WithProjectID(projectID string) Option {
+	return func(o *ClientOptions) {
+		o.ProjectID = projectID
+	}
+}
+
+// WithProtectedDomains sets the domains that must not be deleted.
+// A pattern is a domain, or a wildcard such as "*.example.com" that protects all subdomains.
+func WithProtectedDomains(patterns ...string) Option {
+	return func(o *ClientOptions) {
+		o.ProtectedDomains = patterns
+	}
+}
+
+// WithOverrideProtection allows deleting the domains protected by WithProtectedDomains.
+// It is intended to be passed to a single call.
+//
+// Default: false
+func WithOverrideProtection(override bool) Option {
+	return func(o *ClientOptions) {
+		o.OverrideProtection = override
+	}
+}
+
+// WithMaxRange enables splitting statistics requests whose period spans more than
+// span months into windows queried separately.
+// Zero disables the splitting.
+//
+// Default: 0
+func WithMaxRange(span int) Option {
+	return func(o *ClientOptions) {
+		o.MaxRange = span
+	}
+}
+
+// WithMaxAge sets how many months of statistics are retained, including the current month.
+// The months of a statistics request older than that are clipped before any request,
+// and a period entirely older than that is refused with an error.
+// Zero disables the check.
+//
+// Default: 0
+func WithMaxAge(months int) Option {
+	return func(o *ClientOptions) {
+		o.MaxAge = months
+	}
+}
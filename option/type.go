@@ -4,23 +4,62 @@ import (
 	"net/http"
 	"net/url"
 	"time"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/audit"
 )
 
 type ClientOptions struct {
-	APIToken   string
-	BaseURL    *url.URL
-	HTTPClient *http.Client
-	Timeout    time.Duration
-	Retry      int
+	APIToken                   string
+	BaseURL                    *url.URL
+	HTTPClient                 *http.Client
+	Timeout                    time.Duration
+	Retry                      int
+	MaxPages                   int
+	MaxItems                   int
+	Progress                   func(Progress)
+	PageSize                   int
+	AdaptivePageSize           bool
+	DisableDomainNormalization bool
+	ChunkSize                  int
+	MaxDeletions               int
+	Concurrency                int
+	DomainCacheTTL             time.Duration
+	AuditSink                  audit.Sink
+	ProjectID                  string
+	ProtectedDomains           []string
+	OverrideProtection         bool
+	MaxRange                   int
+	MaxAge                     int
+}
+
+// Progress describes the progress of a pagination.
+// It is defined here so that pagers can receive the callback through options.
+type Progress struct {
+	// Path is the API path being paginated.
+	Path string
+	// Pages is the number of pages fetched so far.
+	Pages int
+	// Items is the number of items yielded so far, by Iter or in the pages returned by GetNextPage.
+	// Items of a page discarded by a *PaginationError are not counted.
+	Items int
+	// Elapsed is the time elapsed since the first page was requested.
+	Elapsed time.Duration
+	// Cursor is the cursor used to fetch the last page, or empty for the first page.
+	Cursor string
+	// Latency is the time taken to fetch the last page.
+	Latency time.Duration
 }
 
 func NewClientOptions(options ...Option) *ClientOptions {
 	baseURL, _ := url.Parse(DefaultBaseURL)
 
 	o := &ClientOptions{
-		BaseURL: baseURL,
-		Timeout: DefaultTimeout,
-		Retry:   DefaultRetry,
+		BaseURL:        baseURL,
+		Timeout:        DefaultTimeout,
+		Retry:          DefaultRetry,
+		ChunkSize:      DefaultChunkSize,
+		Concurrency:    DefaultConcurrency,
+		DomainCacheTTL: DefaultDomainCacheTTL,
 	}
 	for _, option := range options {
 		option(o)
@@ -0,0 +1,44 @@
+package pager
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrRepeatedCursor is returned when the API returns a cursor that was already visited.
+	ErrRepeatedCursor = errors.New("repeated cursor")
+	// ErrEmptyPage is returned when the API returns an empty page that claims more results.
+	ErrEmptyPage = errors.New("empty page with next page")
+	// ErrMaxPages is returned when the maximum number of pages is exceeded.
+	ErrMaxPages = errors.New("maximum number of pages exceeded")
+	// ErrMaxItems is returned when the maximum number of items is exceeded.
+	ErrMaxItems = errors.New("maximum number of items exceeded")
+	// ErrInvalidLimit is returned when the page size is out of the range accepted by the API.
+	ErrInvalidLimit = errors.New("invalid limit")
+
+	_ error = (*PaginationError)(nil)
+)
+
+// PaginationError represents an anomaly detected while paginating.
+type PaginationError struct {
+	Err    error
+	Path   string
+	Cursor string
+	Pages  int
+	Items  int
+}
+
+// Error returns the error message for the PaginationError.
+func (err *PaginationError) Error() string {
+	message := fmt.Sprintf("pagination error: %s: %s", err.Path, err.Err)
+	if err.Cursor != "" {
+		message = fmt.Sprintf("%s: cursor %q", message, err.Cursor)
+	}
+	return fmt.Sprintf("%s (pages: %d, items: %d)", message, err.Pages, err.Items)
+}
+
+// Unwrap returns the underlying anomaly error.
+func (err *PaginationError) Unwrap() error {
+	return err.Err
+}
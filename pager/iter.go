@@ -0,0 +1,124 @@
+package pager
+
+import "iter"
+
+// Map returns an iterator that yields the items with their values converted by f.
+// The metadata of each item is kept as is.
+func Map[T, U any, M metadata](
+	seq iter.Seq2[*Item[T, M], error],
+	f func(T) U,
+) iter.Seq2[*Item[U, M], error] {
+	return func(yield func(*Item[U, M], error) bool) {
+		for item, err := range seq {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			mapped := &Item[U, M]{
+				Value: f(item.Value),
+				Meta:  item.Meta,
+			}
+			if !yield(mapped, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Filter returns an iterator that yields only the values for which f returns true.
+// Errors are always yielded.
+func Filter[V any](
+	seq iter.Seq2[V, error],
+	f func(V) bool,
+) iter.Seq2[V, error] {
+	return func(yield func(V, error) bool) {
+		for value, err := range seq {
+			if err != nil {
+				yield(value, err)
+				return
+			}
+
+			if !f(value) {
+				continue
+			}
+			if !yield(value, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator that yields the values of the items without metadata.
+func Values[T any, M metadata](
+	seq iter.Seq2[*Item[T, M], error],
+) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for item, err := range seq {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			if !yield(item.Value, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Batch returns an iterator that yields the values grouped into slices of n values.
+// The last slice may contain fewer than n values.
+// Values received before an error are yielded before the error.
+func Batch[V any](
+	seq iter.Seq2[V, error],
+	n int,
+) iter.Seq2[[]V, error] {
+	n = max(n, 1)
+
+	return func(yield func([]V, error) bool) {
+		batch := make([]V, 0, n)
+		for value, err := range seq {
+			if err != nil {
+				if len(batch) > 0 && !yield(batch, nil) {
+					return
+				}
+				yield(nil, err)
+				return
+			}
+
+			batch = append(batch, value)
+			if len(batch) < n {
+				continue
+			}
+			if !yield(batch, nil) {
+				return
+			}
+			batch = make([]V, 0, n)
+		}
+		if len(batch) > 0 {
+			yield(batch, nil)
+		}
+	}
+}
+
+// Merge returns an iterator that yields the values of all sequences in order.
+// Each sequence is consumed only after the previous one is exhausted,
+// so no pages of later pagers are fetched when the consumer stops early.
+func Merge[V any](
+	seqs ...iter.Seq2[V, error],
+) iter.Seq2[V, error] {
+	return func(yield func(V, error) bool) {
+		for _, seq := range seqs {
+			for value, err := range seq {
+				if !yield(value, err) {
+					return
+				}
+				if err != nil {
+					return
+				}
+			}
+		}
+	}
+}
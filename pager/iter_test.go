@@ -0,0 +1,130 @@
+package pager
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func collect[V any](t *testing.T, seq func(func(V, error) bool)) ([]V, error) {
+	t.Helper()
+
+	var values []V
+	for value, err := range seq {
+		if err != nil {
+			return values, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func TestMap(t *testing.T) {
+	setupMock(t)
+
+	c := clienttest.NewClient(t)
+	pager := NewPager[int, *Metadata](c, "/pager", nil)
+
+	items, err := collect(t, Map(pager.Iter(t.Context()), strconv.Itoa))
+
+	assert.NoError(t, err)
+	if assert.Len(t, items, 9) {
+		assert.Equal(t, "1", items[0].Value)
+		assert.Equal(t, "9", items[8].Value)
+		assert.True(t, items[0].Meta.HasNext)
+		assert.False(t, items[8].Meta.HasNext)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	setupMock(t)
+
+	c := clienttest.NewClient(t)
+	pager := NewPager[int, *Metadata](c, "/pager", nil)
+
+	values, err := collect(t, Filter(Values(pager.Iter(t.Context())), func(v int) bool {
+		return v%2 == 0
+	}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 4, 6, 8}, values)
+}
+
+func TestValues(t *testing.T) {
+	setupMock(t)
+
+	c := clienttest.NewClient(t)
+	pager := NewPager[int, *Metadata](c, "/pager", nil)
+
+	values, err := collect(t, Values(pager.Iter(t.Context())))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, values)
+}
+
+func TestBatch(t *testing.T) {
+	setupMock(t)
+
+	c := clienttest.NewClient(t)
+	pager := NewPager[int, *Metadata](c, "/pager", nil)
+
+	batches, err := collect(t, Batch(Values(pager.Iter(t.Context())), 4))
+
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{1, 2, 3, 4}, {5, 6, 7, 8}, {9}}, batches)
+}
+
+func TestMerge(t *testing.T) {
+	setupMock(t)
+
+	c := clienttest.NewClient(t)
+	pager1 := NewPager[int, *Metadata](c, "/pager", nil)
+	pager2 := NewPager[int, *Metadata](c, "/empty", nil)
+	pager3 := NewPager[int, *Metadata](c, "/pager", nil)
+
+	values, err := collect(t, Values(Merge(pager1.Iter(t.Context()), pager2.Iter(t.Context()), pager3.Iter(t.Context()))))
+
+	assert.NoError(t, err)
+	assert.Len(t, values, 18)
+}
+
+func TestMerge_breakEarly(t *testing.T) {
+	setupMock(t)
+
+	c := clienttest.NewClient(t)
+	pager1 := NewPager[int, *Metadata](c, "/pager", nil)
+	pager2 := NewPager[int, *Metadata](c, "/pager", nil)
+
+	for value, err := range Values(Merge(pager1.Iter(t.Context()), pager2.Iter(t.Context()))) {
+		assert.NoError(t, err)
+		if value == 4 {
+			break
+		}
+	}
+
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+	assert.True(t, pager2.HasNextPage())
+}
+
+func TestBatch_error(t *testing.T) {
+	setupMock(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/error",
+		httpmock.NewErrorResponder(errors.New("test error")),
+	)
+
+	c := clienttest.NewClient(t)
+	pager1 := NewPager[int, *Metadata](c, "/pager", nil)
+	pager2 := NewPager[int, *Metadata](c, "/error", nil)
+
+	batches, err := collect(t, Batch(Values(Merge(pager1.Iter(t.Context()), pager2.Iter(t.Context()))), 4))
+
+	assert.ErrorContains(t, err, "test error")
+	assert.Equal(t, [][]int{{1, 2, 3, 4}, {5, 6, 7, 8}, {9}}, batches)
+}
@@ -3,9 +3,14 @@ package pager
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"iter"
+	"net"
 	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/morisawa-inc/morisawafonts-webfont-go/client"
 	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
@@ -19,6 +24,14 @@ type Pager[T any, M metadata] struct {
 	values   url.Values
 	options  []option.Option
 	nextPage bool
+	cursors  map[string]struct{}
+	pages    int
+	items    int
+	yielded  int
+	started  time.Time
+	cursor   string
+	latency  time.Duration
+	err      error
 }
 
 // NewPager creates a new pager instance for paginating through API results.
@@ -28,12 +41,27 @@ func NewPager[T any, M metadata](
 	values url.Values,
 	options ...option.Option,
 ) *Pager[T, M] {
+	cursors := map[string]struct{}{}
+	if cursor := values.Get(Cursor); cursor != "" {
+		cursors[cursor] = struct{}{}
+	}
+
 	return &Pager[T, M]{
 		client:   c,
 		path:     path,
 		values:   values,
 		options:  options,
 		nextPage: true,
+		cursors:  cursors,
+	}
+}
+
+// NewErrorPager creates a pager that returns the error when fetching the first page.
+// It is used when the input is found to be invalid before any request.
+func NewErrorPager[T any, M metadata](err error) *Pager[T, M] {
+	return &Pager[T, M]{
+		nextPage: true,
+		err:      err,
 	}
 }
 
@@ -44,20 +72,79 @@ func (p *Pager[T, M]) HasNextPage() bool {
 
 // GetNextPage fetches the next page of results.
 // Returns io.EOF when no more pages are available.
+// Returns a *PaginationError when the API responses look like a runaway pagination
+// or the configured maximum number of pages or items is exceeded.
 func (p *Pager[T, M]) GetNextPage(ctx context.Context) (*Page[T, M], error) {
+	page, err := p.next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.yielded += len(page.Result)
+	p.report()
+	return page, nil
+}
+
+// next fetches the next page without reporting the progress.
+func (p *Pager[T, M]) next(ctx context.Context) (*Page[T, M], error) {
 	if !p.nextPage {
 		return nil, io.EOF
 	}
+	if p.err != nil {
+		p.nextPage = false
+		return nil, p.err
+	}
 
-	var page Page[T, M]
-	err := p.client.Get(ctx, p.path, p.values, &page, p.options...)
+	o := p.client.Options(p.options...)
+	if o.MaxPages > 0 && p.pages >= o.MaxPages {
+		return nil, p.fail(ErrMaxPages)
+	}
+
+	limit, err := p.limit(o)
 	if err != nil {
 		return nil, err
 	}
 
+	now := time.Now()
+	if p.started.IsZero() {
+		p.started = now
+	}
+	cursor := p.values.Get(Cursor)
+
+	var page Page[T, M]
+	for {
+		err = p.client.Get(ctx, p.path, p.values, &page, p.options...)
+		if err == nil {
+			break
+		}
+		if !o.AdaptivePageSize || !isTimeout(err) || ctx.Err() != nil || limit <= 1 {
+			return nil, err
+		}
+
+		limit = max(limit/2, 1)
+		p.values.Set(Limit, strconv.Itoa(limit))
+	}
+
+	p.pages++
+	p.items += len(page.Result)
+	p.cursor = cursor
+	p.latency = time.Since(now)
+	if o.MaxItems > 0 && p.items > o.MaxItems {
+		return nil, p.fail(ErrMaxItems)
+	}
+
 	nextPage := page.Meta.HasNextPage()
 	nextCursor := page.Meta.NextPageCursor()
 	if nextPage && nextCursor != nil {
+		if len(page.Result) == 0 {
+			return nil, p.fail(ErrEmptyPage)
+		}
+		if _, ok := p.cursors[*nextCursor]; ok {
+			p.values.Set(Cursor, *nextCursor)
+			return nil, p.fail(ErrRepeatedCursor)
+		}
+		p.cursors[*nextCursor] = struct{}{}
+
 		if p.values == nil {
 			p.values = url.Values{}
 		}
@@ -69,12 +156,74 @@ func (p *Pager[T, M]) GetNextPage(ctx context.Context) (*Page[T, M], error) {
 	return &page, nil
 }
 
+// report calls the progress callback, if any, after a page is yielded.
+func (p *Pager[T, M]) report() {
+	o := p.client.Options(p.options...)
+	if o.Progress == nil {
+		return
+	}
+	o.Progress(Progress{
+		Path:    p.path,
+		Pages:   p.pages,
+		Items:   p.yielded,
+		Elapsed: time.Since(p.started),
+		Cursor:  p.cursor,
+		Latency: p.latency,
+	})
+}
+
+// limit validates the page size and applies the default page size from the options.
+// It returns 0 when the API default is used.
+func (p *Pager[T, M]) limit(o *option.ClientOptions) (int, error) {
+	limit := o.PageSize
+	if limit == 0 && o.AdaptivePageSize {
+		limit = option.MaxPageSize
+	}
+	if raw := p.values.Get(Limit); raw != "" {
+		var err error
+		limit, err = strconv.Atoi(raw)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidLimit, raw)
+		}
+	} else if limit == 0 {
+		return 0, nil
+	}
+	if limit < 1 || limit > option.MaxPageSize {
+		return 0, fmt.Errorf("%w: %d: must be between 1 and %d", ErrInvalidLimit, limit, option.MaxPageSize)
+	}
+
+	if p.values == nil {
+		p.values = url.Values{}
+	}
+	p.values.Set(Limit, strconv.Itoa(limit))
+	return limit, nil
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+}
+
+func (p *Pager[T, M]) fail(err error) error {
+	p.nextPage = false
+
+	return &PaginationError{
+		Err:    err,
+		Path:   p.path,
+		Cursor: p.values.Get(Cursor),
+		Pages:  p.pages,
+		Items:  p.items,
+	}
+}
+
 // Iter returns an iterator that yields individual items from all pages.
 // The iterator automatically handles pagination and stops when all pages are consumed.
+// The progress is reported after the items of each page are yielded,
+// or when the iteration is stopped in the middle of a page.
 func (p *Pager[T, M]) Iter(ctx context.Context) iter.Seq2[*Item[T, M], error] {
 	return func(yield func(*Item[T, M], error) bool) {
 		for p.nextPage {
-			page, err := p.GetNextPage(ctx)
+			page, err := p.next(ctx)
 			if err != nil {
 				yield(nil, err)
 				return
@@ -85,10 +234,13 @@ func (p *Pager[T, M]) Iter(ctx context.Context) iter.Seq2[*Item[T, M], error] {
 					Value: value,
 					Meta:  page.Meta,
 				}
+				p.yielded++
 				if !yield(item, nil) {
+					p.report()
 					return
 				}
 			}
+			p.report()
 		}
 	}
 }
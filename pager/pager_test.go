@@ -1,14 +1,17 @@
 package pager
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/jarcoal/httpmock"
 	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
@@ -59,6 +62,32 @@ func setupMock(t *testing.T) {
 			})
 		},
 	)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/loop",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewJsonResponse(http.StatusOK, Page[int, *Metadata]{
+				Result: []int{1},
+				Meta: &Metadata{
+					HasNext:    true,
+					NextCursor: lo.ToPtr("cursor1"),
+				},
+			})
+		},
+	)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/runaway",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewJsonResponse(http.StatusOK, Page[int, *Metadata]{
+				Result: []int{},
+				Meta: &Metadata{
+					HasNext:    true,
+					NextCursor: lo.ToPtr("cursor1"),
+				},
+			})
+		},
+	)
 }
 
 func TestPager_GetNextPage(t *testing.T) {
@@ -170,3 +199,247 @@ func TestPager_Iter(t *testing.T) {
 		})
 	}
 }
+
+func TestPager_Iter_anomaly(t *testing.T) {
+	tests := []struct {
+		name      string
+		path      string
+		options   []option.Option
+		wantErr   error
+		wantPages int
+		wantItems int
+	}{
+		{
+			"repeated cursor",
+			"/loop",
+			nil,
+			ErrRepeatedCursor,
+			2,
+			2,
+		},
+		{
+			"empty page",
+			"/runaway",
+			nil,
+			ErrEmptyPage,
+			1,
+			0,
+		},
+		{
+			"max pages",
+			"/pager",
+			[]option.Option{option.WithMaxPages(2)},
+			ErrMaxPages,
+			2,
+			6,
+		},
+		{
+			"max items",
+			"/pager",
+			[]option.Option{option.WithMaxItems(5)},
+			ErrMaxItems,
+			2,
+			6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupMock(t)
+
+			c := clienttest.NewClient(t)
+			pager := NewPager[int, *Metadata](c, tt.path, nil, tt.options...)
+
+			var err error
+			for _, err = range pager.Iter(t.Context()) {
+				if err != nil {
+					break
+				}
+			}
+
+			var paginationErr *PaginationError
+			if assert.ErrorAs(t, err, &paginationErr) {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.path, paginationErr.Path)
+				assert.Equal(t, tt.wantPages, paginationErr.Pages)
+				assert.Equal(t, tt.wantItems, paginationErr.Items)
+			}
+			assert.False(t, pager.HasNextPage())
+		})
+	}
+}
+
+func TestPager_Iter_progress(t *testing.T) {
+	setupMock(t)
+
+	var progress []Progress
+
+	c := clienttest.NewClient(t)
+	pager := NewPager[int, *Metadata](c, "/pager", nil, WithProgress(func(p Progress) {
+		progress = append(progress, p)
+	}))
+
+	for _, err := range pager.Iter(t.Context()) {
+		assert.NoError(t, err)
+	}
+
+	if assert.Len(t, progress, 3) {
+		for i, p := range progress {
+			assert.Equal(t, "/pager", p.Path)
+			assert.Equal(t, i+1, p.Pages)
+			assert.Equal(t, (i+1)*3, p.Items)
+			assert.Equal(t, lo.Ternary(i == 0, "", fmt.Sprintf("cursor%d", i)), p.Cursor)
+			assert.GreaterOrEqual(t, p.Elapsed, p.Latency)
+		}
+	}
+}
+
+func TestPager_Iter_progressStopped(t *testing.T) {
+	setupMock(t)
+
+	var progress []Progress
+
+	c := clienttest.NewClient(t)
+	pager := NewPager[int, *Metadata](c, "/pager", nil, WithProgress(func(p Progress) {
+		progress = append(progress, p)
+	}))
+
+	for item, err := range pager.Iter(t.Context()) {
+		assert.NoError(t, err)
+		if item.Value == 5 {
+			break
+		}
+	}
+
+	if assert.Len(t, progress, 2) {
+		assert.Equal(t, 3, progress[0].Items)
+		assert.Equal(t, 5, progress[1].Items)
+		assert.Equal(t, 2, progress[1].Pages)
+	}
+}
+
+func TestPager_GetNextPage_progressMaxItems(t *testing.T) {
+	setupMock(t)
+
+	var progress []Progress
+
+	c := clienttest.NewClient(t)
+	pager := NewPager[int, *Metadata](c, "/pager", nil, option.WithMaxItems(5), WithProgress(func(p Progress) {
+		progress = append(progress, p)
+	}))
+
+	_, err := pager.GetNextPage(t.Context())
+	assert.NoError(t, err)
+	_, err = pager.GetNextPage(t.Context())
+	assert.ErrorIs(t, err, ErrMaxItems)
+
+	// The page discarded by the limit is not reported as yielded.
+	if assert.Len(t, progress, 1) {
+		assert.Equal(t, 3, progress[0].Items)
+	}
+}
+
+func TestPager_GetNextPage_pageSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    url.Values
+		options   []option.Option
+		wantLimit string
+		wantErr   error
+	}{
+		{
+			"default",
+			nil,
+			nil,
+			"",
+			nil,
+		},
+		{
+			"page size",
+			nil,
+			[]option.Option{option.WithPageSize(50)},
+			"50",
+			nil,
+		},
+		{
+			"input overrides page size",
+			url.Values{Limit: {"10"}},
+			[]option.Option{option.WithPageSize(50)},
+			"10",
+			nil,
+		},
+		{
+			"page size too large",
+			nil,
+			[]option.Option{option.WithPageSize(option.MaxPageSize + 1)},
+			"",
+			ErrInvalidLimit,
+		},
+		{
+			"limit too small",
+			url.Values{Limit: {"0"}},
+			nil,
+			"",
+			ErrInvalidLimit,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.Activate(t)
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				"https://api.morisawafonts.com/webfont/v1/limit",
+				func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, tt.wantLimit, req.URL.Query().Get(Limit))
+
+					return httpmock.NewJsonResponse(http.StatusOK, Page[int, *Metadata]{
+						Result: []int{1},
+						Meta:   &Metadata{},
+					})
+				},
+			)
+
+			c := clienttest.NewClient(t)
+			pager := NewPager[int, *Metadata](c, "/limit", tt.values, tt.options...)
+
+			_, err := pager.GetNextPage(t.Context())
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Zero(t, httpmock.GetTotalCallCount())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, httpmock.GetTotalCallCount())
+			}
+		})
+	}
+}
+
+func TestPager_GetNextPage_adaptivePageSize(t *testing.T) {
+	var limits []string
+
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/slow",
+		func(req *http.Request) (*http.Response, error) {
+			limit := req.URL.Query().Get(Limit)
+			limits = append(limits, limit)
+			if limit != "25" {
+				return nil, context.DeadlineExceeded
+			}
+
+			return httpmock.NewJsonResponse(http.StatusOK, Page[int, *Metadata]{
+				Result: []int{1},
+				Meta:   &Metadata{},
+			})
+		},
+	)
+
+	c := clienttest.NewClient(t, option.WithRetry(0))
+	pager := NewPager[int, *Metadata](c, "/slow", nil, option.WithAdaptivePageSize(true))
+
+	_, err := pager.GetNextPage(t.Context())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"100", "50", "25"}, limits)
+}
@@ -0,0 +1,13 @@
+package pager
+
+import "github.com/morisawa-inc/morisawafonts-webfont-go/option"
+
+// Progress describes the progress of a pagination.
+type Progress = option.Progress
+
+// WithProgress sets a callback that is called after the items of each page are yielded.
+func WithProgress(f func(Progress)) option.Option {
+	return func(o *option.ClientOptions) {
+		o.Progress = f
+	}
+}
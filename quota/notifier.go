@@ -0,0 +1,157 @@
+package quota
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+// Alert describes a threshold crossed by the usage.
+type Alert struct {
+	// Threshold is the percentage of the limit crossed.
+	Threshold int
+	Status    *Status
+}
+
+// Notifier receives alerts.
+type Notifier interface {
+	Notify(ctx context.Context, alert *Alert) error
+}
+
+// NamedNotifier is a Notifier with a name identifying it across restarts.
+// The notifiers that received an alert are recorded by name, so the names must be unique
+// among Config.Notifiers. Notifiers without a name are identified by their index in Config.Notifiers,
+// which changes when notifiers are added, removed or reordered.
+type NamedNotifier interface {
+	Notifier
+	Name() string
+}
+
+// Named returns the notifier identified by the name.
+func Named(name string, n Notifier) NamedNotifier {
+	return &namedNotifier{Notifier: n, name: name}
+}
+
+type namedNotifier struct {
+	Notifier
+	name string
+}
+
+// Name returns the name of the notifier.
+func (n *namedNotifier) Name() string {
+	return n.name
+}
+
+// NotifierFunc is an adapter to use a function as a Notifier.
+type NotifierFunc func(ctx context.Context, alert *Alert) error
+
+// Notify calls f(ctx, alert).
+func (f NotifierFunc) Notify(ctx context.Context, alert *Alert) error {
+	return f(ctx, alert)
+}
+
+// LogNotifier logs alerts at the warning level.
+type LogNotifier struct {
+	logger *slog.Logger
+}
+
+// NewLogNotifier creates a new LogNotifier instance.
+// slog.Default is used if the logger is nil.
+func NewLogNotifier(logger *slog.Logger) *LogNotifier {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LogNotifier{
+		logger: logger,
+	}
+}
+
+// Name returns "log".
+func (n *LogNotifier) Name() string {
+	return "log"
+}
+
+// Notify logs the alert.
+func (n *LogNotifier) Notify(ctx context.Context, alert *Alert) error {
+	n.logger.WarnContext(ctx, "page view quota threshold crossed",
+		slog.Int("threshold", alert.Threshold),
+		slog.String("period", alert.Status.Period.String()),
+		slog.Int("limit", alert.Status.Limit),
+		slog.Int("used", alert.Status.Used),
+		slog.Int("projected", alert.Status.Projected),
+	)
+	return nil
+}
+
+// WebhookPayload is the JSON body posted by WebhookNotifier.
+type WebhookPayload struct {
+	Threshold        int     `json:"threshold"`
+	Period           string  `json:"period"`
+	Limit            int     `json:"limit"`
+	Used             int     `json:"used"`
+	Percent          float64 `json:"percent"`
+	Projected        int     `json:"projected"`
+	ProjectedPercent float64 `json:"projected_percent"`
+}
+
+// WebhookNotifier posts alerts as JSON to a URL.
+type WebhookNotifier struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewWebhookNotifier creates a new WebhookNotifier instance.
+// http.DefaultClient is used if the client is nil.
+func NewWebhookNotifier(url string, httpClient *http.Client) *WebhookNotifier {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &WebhookNotifier{
+		url:        url,
+		httpClient: httpClient,
+	}
+}
+
+// Name returns "webhook:" followed by the URL.
+func (n *WebhookNotifier) Name() string {
+	return "webhook:" + n.url
+}
+
+// Notify posts the alert.
+// An error is returned if the response status is not 2xx.
+func (n *WebhookNotifier) Notify(ctx context.Context, alert *Alert) error {
+	body, err := json.Marshal(&WebhookPayload{
+		Threshold:        alert.Threshold,
+		Period:           alert.Status.Period.String(),
+		Limit:            alert.Status.Limit,
+		Used:             alert.Status.Used,
+		Percent:          alert.Status.Percent(),
+		Projected:        alert.Status.Projected,
+		ProjectedPercent: alert.Status.ProjectedPercent(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("quota: webhook responded with status %d", res.StatusCode)
+	}
+	return nil
+}
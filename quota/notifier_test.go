@@ -0,0 +1,74 @@
+package quota
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/resource/stats"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAlert() *Alert {
+	return &Alert{
+		Threshold: 80,
+		Status: &Status{
+			Period:    stats.NewMonth(2025, 6).Period(),
+			Limit:     1000,
+			Used:      850,
+			Projected: 2000,
+		},
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var payload WebhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	n := NewWebhookNotifier(server.URL, nil)
+	err := n.Notify(t.Context(), newAlert())
+
+	assert.NoError(t, err)
+	assert.Equal(t, WebhookPayload{
+		Threshold:        80,
+		Period:           "2025-06/2025-06",
+		Limit:            1000,
+		Used:             850,
+		Percent:          85,
+		Projected:        2000,
+		ProjectedPercent: 200,
+	}, payload)
+	assert.Equal(t, "webhook:"+server.URL, n.Name())
+}
+
+func TestWebhookNotifier_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	n := NewWebhookNotifier(server.URL, server.Client())
+	err := n.Notify(t.Context(), newAlert())
+
+	assert.EqualError(t, err, "quota: webhook responded with status 502")
+}
+
+func TestLogNotifier(t *testing.T) {
+	var buf bytes.Buffer
+	n := NewLogNotifier(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	err := n.Notify(t.Context(), newAlert())
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "level=WARN")
+	assert.Contains(t, buf.String(), "threshold=80 period=2025-06/2025-06 limit=1000 used=850 projected=2000")
+}
@@ -0,0 +1,236 @@
+// Package quota provides monitoring of the monthly page view allowance.
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/client"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/resource/stats"
+)
+
+// DefaultThresholds is the percentages of the limit notified by default.
+var DefaultThresholds = []int{50, 80, 100}
+
+var now = time.Now
+
+// Config configures a Monitor.
+type Config struct {
+	// Limit is the monthly page view allowance.
+	Limit int
+	// Thresholds is the percentages of the limit to notify. Default: DefaultThresholds
+	Thresholds []int
+	Notifiers  []Notifier
+	// StatePath is the file persisting the notified thresholds across restarts.
+	// The state is kept in memory only if empty.
+	StatePath string
+	// OnError receives the errors of the checks in Run.
+	// Run returns the first error if nil.
+	OnError func(error)
+}
+
+// Status is the page view usage of the current billing period.
+type Status struct {
+	Period stats.Period
+	Limit  int
+	Used   int
+	// Projected is the usage at the end of the period at the current run rate.
+	Projected int
+	// Crossed is the thresholds notified by the check, in ascending order.
+	Crossed []int
+}
+
+// Percent returns Used relative to Limit in percent.
+func (s *Status) Percent() float64 {
+	return percent(s.Used, s.Limit)
+}
+
+// ProjectedPercent returns Projected relative to Limit in percent.
+func (s *Status) ProjectedPercent() float64 {
+	return percent(s.Projected, s.Limit)
+}
+
+// Monitor polls the page views of the current month and notifies when thresholds are crossed.
+// Each threshold is notified once per month to each notifier;
+// when some notifiers fail, only those are notified again by the next check.
+type Monitor struct {
+	pv     *stats.PV
+	config Config
+
+	mu    sync.Mutex
+	state *State
+}
+
+// NewMonitor creates a new Monitor instance.
+func NewMonitor(c *client.Client, config Config) *Monitor {
+	if config.Thresholds == nil {
+		config.Thresholds = DefaultThresholds
+	}
+	config.Thresholds = slices.Sorted(slices.Values(config.Thresholds))
+
+	return &Monitor{
+		pv:     stats.NewPV(c),
+		config: config,
+	}
+}
+
+// Check retrieves the page views of the current month and notifies the thresholds newly crossed.
+// A threshold is recorded as notified only if all notifiers succeed, so that it is retried by the next check.
+func (m *Monitor) Check(ctx context.Context, options ...option.Option) (*Status, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+
+	t := now()
+	month := stats.MonthOf(t)
+	period := stats.NewPeriod(month, month)
+	result, err := m.pv.Get(ctx, &stats.PVGetInput{Period: &period}, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{
+		Period:    period,
+		Limit:     m.config.Limit,
+		Used:      result.PV.Total,
+		Projected: project(result.PV.Total, month, t),
+	}
+
+	if m.state.Period != period.String() {
+		m.state = &State{Period: period.String()}
+	}
+
+	var (
+		errs     []error
+		notified bool
+	)
+	for _, threshold := range m.config.Thresholds {
+		if slices.Contains(m.state.Notified, threshold) || status.Percent() < float64(threshold) {
+			continue
+		}
+
+		notified = true
+		if err := m.notify(ctx, &Alert{Threshold: threshold, Status: status}); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		m.state.Notified = append(m.state.Notified, threshold)
+		status.Crossed = append(status.Crossed, threshold)
+	}
+
+	if notified {
+		errs = append(errs, m.save())
+	}
+	return status, errors.Join(errs...)
+}
+
+// Run checks every interval until the context is canceled.
+func (m *Monitor) Run(ctx context.Context, interval time.Duration, options ...option.Option) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := m.Check(ctx, options...); err != nil {
+			if m.config.OnError == nil {
+				return err
+			}
+			m.config.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// notify sends the alert to the notifiers that have not received it yet,
+// and records the deliveries in the state until every notifier has received it.
+// Names of notifiers no longer configured are dropped from the record.
+func (m *Monitor) notify(ctx context.Context, alert *Alert) error {
+	var (
+		delivered []string
+		errs      []error
+	)
+	for i, n := range m.config.Notifiers {
+		name := notifierName(i, n)
+		if slices.Contains(m.state.Delivered[alert.Threshold], name) {
+			delivered = append(delivered, name)
+			continue
+		}
+		if err := n.Notify(ctx, alert); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delivered = append(delivered, name)
+	}
+
+	if len(errs) == 0 {
+		delete(m.state.Delivered, alert.Threshold)
+		return nil
+	}
+	if m.state.Delivered == nil {
+		m.state.Delivered = map[int][]string{}
+	}
+	m.state.Delivered[alert.Threshold] = delivered
+	return errors.Join(errs...)
+}
+
+// notifierName returns the name of the notifier, or its index for notifiers without a name.
+func notifierName(i int, n Notifier) string {
+	if named, ok := n.(NamedNotifier); ok {
+		return named.Name()
+	}
+	return fmt.Sprintf("#%d", i)
+}
+
+func (m *Monitor) load() error {
+	if m.state != nil {
+		return nil
+	}
+	if m.config.StatePath == "" {
+		m.state = &State{}
+		return nil
+	}
+
+	state, err := LoadState(m.config.StatePath)
+	if err != nil {
+		return err
+	}
+	m.state = state
+	return nil
+}
+
+func (m *Monitor) save() error {
+	if m.config.StatePath == "" {
+		return nil
+	}
+	return m.state.Save(m.config.StatePath)
+}
+
+// project extrapolates the usage to the end of the month from the time elapsed in the month.
+func project(used int, month stats.Month, t time.Time) int {
+	start := month.Time()
+	end := start.AddDate(0, 1, 0)
+	elapsed := t.Sub(start)
+	if elapsed <= 0 {
+		return used
+	}
+	return int(float64(used) * float64(end.Sub(start)) / float64(min(elapsed, end.Sub(start))))
+}
+
+func percent(value, limit int) float64 {
+	if limit <= 0 {
+		return 0
+	}
+	return float64(value) / float64(limit) * 100
+}
@@ -0,0 +1,224 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/resource/stats"
+	"github.com/stretchr/testify/assert"
+)
+
+func setNow(t *testing.T, year int, month time.Month, day int) {
+	t.Helper()
+
+	original := now
+	now = func() time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, stats.Location)
+	}
+	t.Cleanup(func() {
+		now = original
+	})
+}
+
+func setupPVMock(t *testing.T, total *int) {
+	t.Helper()
+
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewJsonResponse(http.StatusOK, &stats.PVGetResponse{
+				PV: &stats.PVGetResult{Total: *total},
+				Meta: &stats.PVGetMetadata{
+					ProjectID: "project",
+					From:      req.URL.Query().Get("from"),
+					To:        req.URL.Query().Get("to"),
+				},
+			})
+		},
+	)
+}
+
+func TestMonitor_Check(t *testing.T) {
+	setNow(t, 2025, 6, 11)
+	total := 400
+	setupPVMock(t, &total)
+
+	var alerts []int
+	notifier := NotifierFunc(func(_ context.Context, alert *Alert) error {
+		alerts = append(alerts, alert.Threshold)
+		return nil
+	})
+
+	c := clienttest.NewClient(t)
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	m := NewMonitor(c, Config{
+		Limit:     1000,
+		Notifiers: []Notifier{notifier},
+		StatePath: statePath,
+	})
+
+	status, err := m.Check(t.Context())
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Status{
+		Period:    stats.NewMonth(2025, 6).Period(),
+		Limit:     1000,
+		Used:      400,
+		Projected: 1200,
+	}, status)
+	assert.InDelta(t, 40.0, status.Percent(), 1e-9)
+	assert.InDelta(t, 120.0, status.ProjectedPercent(), 1e-9)
+	assert.Empty(t, alerts)
+
+	// Crossing two thresholds at once notifies both.
+	total = 850
+	status, err = m.Check(t.Context())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{50, 80}, status.Crossed)
+	assert.Equal(t, []int{50, 80}, alerts)
+
+	// A restarted monitor does not notify again.
+	m = NewMonitor(c, Config{
+		Limit:     1000,
+		Notifiers: []Notifier{notifier},
+		StatePath: statePath,
+	})
+	total = 1000
+	status, err = m.Check(t.Context())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{100}, status.Crossed)
+	assert.Equal(t, []int{50, 80, 100}, alerts)
+
+	// The next month starts over.
+	setNow(t, 2025, 7, 1)
+	total = 600
+	status, err = m.Check(t.Context())
+
+	assert.NoError(t, err)
+	assert.Equal(t, stats.NewMonth(2025, 7).Period(), status.Period)
+	assert.Equal(t, []int{50}, status.Crossed)
+	assert.Equal(t, []int{50, 80, 100, 50}, alerts)
+
+	state, err := LoadState(statePath)
+	assert.NoError(t, err)
+	assert.Equal(t, &State{Period: "2025-07/2025-07", Notified: []int{50}}, state)
+}
+
+func TestMonitor_Check_notifierError(t *testing.T) {
+	setNow(t, 2025, 6, 11)
+	total := 600
+	setupPVMock(t, &total)
+
+	fail := true
+	var alerts []int
+	c := clienttest.NewClient(t)
+	m := NewMonitor(c, Config{
+		Limit:      1000,
+		Thresholds: []int{50},
+		Notifiers: []Notifier{NotifierFunc(func(_ context.Context, alert *Alert) error {
+			alerts = append(alerts, alert.Threshold)
+			if fail {
+				return errors.New("unavailable")
+			}
+			return nil
+		})},
+	})
+
+	status, err := m.Check(t.Context())
+
+	assert.EqualError(t, err, "unavailable")
+	assert.Empty(t, status.Crossed)
+
+	// The threshold is retried until notified.
+	fail = false
+	status, err = m.Check(t.Context())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{50}, status.Crossed)
+
+	status, err = m.Check(t.Context())
+
+	assert.NoError(t, err)
+	assert.Empty(t, status.Crossed)
+	assert.Equal(t, []int{50, 50}, alerts)
+}
+
+func TestMonitor_Check_partialDelivery(t *testing.T) {
+	setNow(t, 2025, 6, 11)
+	total := 600
+	setupPVMock(t, &total)
+
+	fail := true
+	var delivered []string
+	log := Named("log", NotifierFunc(func(_ context.Context, _ *Alert) error {
+		delivered = append(delivered, "log")
+		return nil
+	}))
+	webhook := Named("webhook", NotifierFunc(func(_ context.Context, _ *Alert) error {
+		if fail {
+			return errors.New("unavailable")
+		}
+		delivered = append(delivered, "webhook")
+		return nil
+	}))
+	notifiers := []Notifier{log, webhook}
+	statePath := filepath.Join(t.TempDir(), "state.json")
+
+	c := clienttest.NewClient(t)
+	m := NewMonitor(c, Config{Limit: 1000, Thresholds: []int{50}, Notifiers: notifiers, StatePath: statePath})
+	status, err := m.Check(t.Context())
+
+	assert.EqualError(t, err, "unavailable")
+	assert.Empty(t, status.Crossed)
+	assert.Equal(t, []string{"log"}, delivered)
+
+	state, err := LoadState(statePath)
+	assert.NoError(t, err)
+	assert.Equal(t, &State{Period: "2025-06/2025-06", Delivered: map[int][]string{50: {"log"}}}, state)
+
+	// Only the failed notifier is retried, even after a restart reordering the notifiers.
+	fail = false
+	notifiers = []Notifier{webhook, log}
+	m = NewMonitor(c, Config{Limit: 1000, Thresholds: []int{50}, Notifiers: notifiers, StatePath: statePath})
+	status, err = m.Check(t.Context())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{50}, status.Crossed)
+	assert.Equal(t, []string{"log", "webhook"}, delivered)
+
+	state, err = LoadState(statePath)
+	assert.NoError(t, err)
+	assert.Equal(t, &State{Period: "2025-06/2025-06", Notified: []int{50}}, state)
+}
+
+func TestMonitor_Run(t *testing.T) {
+	setNow(t, 2025, 6, 11)
+	total := 600
+	setupPVMock(t, &total)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	c := clienttest.NewClient(t)
+	m := NewMonitor(c, Config{
+		Limit: 1000,
+		Notifiers: []Notifier{NotifierFunc(func(context.Context, *Alert) error {
+			cancel()
+			return nil
+		})},
+	})
+
+	err := m.Run(ctx, time.Millisecond)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
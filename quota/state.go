@@ -0,0 +1,64 @@
+package quota
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// State is the thresholds notified in a billing period.
+type State struct {
+	// Period is the billing period, formatted as stats.Period.String.
+	Period string `json:"period"`
+	// Notified is the thresholds delivered to all notifiers.
+	Notified []int `json:"notified"`
+	// Delivered is the names of the notifiers that received the alert of a threshold
+	// not delivered to all of them yet, so that only the failed notifiers are retried.
+	// See NamedNotifier for the names.
+	Delivered map[int][]string `json:"delivered,omitempty"`
+}
+
+// LoadState reads the state from the file.
+// An empty state is returned if the file does not exist.
+func LoadState(path string) (*State, error) {
+	raw, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return &State{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state State
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Save writes the state to the file, replacing it atomically.
+func (s *State) Save(path string) error {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/parallel"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+	"github.com/samber/lo"
+)
+
+// AddAll adds the specified domains in chunks of option.WithChunkSize,
+// running up to option.WithConcurrency requests at once.
+// The domains of all successful chunks are aggregated into the result,
+// which is returned along with a *BatchError when some chunks failed.
+// As with Add, the registered domains are listed once before the requests.
+// Failures to record audit records are returned as *audit.Error without failing the chunks.
+func (d *Domains) AddAll(
+	ctx context.Context,
+	domains []string,
+	options ...option.Option,
+) (*AddResult, error) {
+	return d.addAll(ctx, domains, nil, options...)
+}
+
+// addAll adds the domains in chunks, telling the already registered domains apart by before,
+// which is listed first if nil.
+func (d *Domains) addAll(
+	ctx context.Context,
+	domains []string,
+	before *Set,
+	options ...option.Option,
+) (*AddResult, error) {
+	requested := domains
+	domains, err := d.normalize(domains, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if before == nil {
+		before, err = d.LoadSet(ctx, options...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	chunks := d.chunk(domains, options...)
+	results := make([]*AddResult, len(chunks))
+	err = d.each(ctx, chunks, func(ctx context.Context, i int, chunk []string) error {
+		result, err := d.add(ctx, chunk, options...)
+		results[i] = result
+		return err
+	}, options...)
+
+	result := &AddResult{}
+	for _, r := range results {
+		if r != nil {
+			result.Domains = append(result.Domains, r.Domains...)
+		}
+	}
+	result.Outcomes = outcomes(requested, result, before)
+
+	var batchErr *BatchError
+	if errors.As(err, &batchErr) {
+		failed := lo.Keyify(lo.Map(batchErr.Domains(), func(domain string, _ int) string { return key(domain) }))
+		for _, outcome := range result.Outcomes {
+			if _, ok := failed[key(outcome.Requested)]; ok && outcome.Status == AddStatusMissing {
+				outcome.Status = AddStatusFailed
+			}
+		}
+	}
+	return result, err
+}
+
+// DeleteAll removes the specified domains in chunks of option.WithChunkSize,
+// running up to option.WithConcurrency requests at once.
+// A *BatchError is returned when some chunks failed.
+// Failures to record audit records are returned as *audit.Error without failing the chunks.
+func (d *Domains) DeleteAll(
+	ctx context.Context,
+	domains []string,
+	options ...option.Option,
+) error {
+	domains, err := d.normalize(domains, options...)
+	if err != nil {
+		return err
+	}
+	if err := d.checkProtected(domains, options...); err != nil {
+		return err
+	}
+
+	chunks := d.chunk(domains, options...)
+	return d.each(ctx, chunks, func(ctx context.Context, _ int, chunk []string) error {
+		return d.Delete(ctx, chunk, options...)
+	}, options...)
+}
+
+func (d *Domains) chunk(domains []string, options ...option.Option) [][]string {
+	return lo.Chunk(domains, max(d.client.Options(options...).ChunkSize, 1))
+}
+
+// each calls f for each chunk with bounded concurrency and collects the failures.
+// Chunks whose only error is an *audit.Error succeeded, so the audit errors are returned
+// alongside the *BatchError instead of within it.
+func (d *Domains) each(
+	ctx context.Context,
+	chunks [][]string,
+	f func(ctx context.Context, i int, chunk []string) error,
+	options ...option.Option,
+) error {
+	errs := parallel.Each(ctx, len(chunks), d.client.Options(options...).Concurrency, func(ctx context.Context, i int) error {
+		return f(ctx, i, chunks[i])
+	})
+
+	var (
+		batchErr  = &BatchError{Chunks: len(chunks)}
+		auditErrs []error
+	)
+	for i, err := range errs {
+		switch {
+		case err == nil:
+		case auditOnly(err):
+			auditErrs = append(auditErrs, err)
+		default:
+			batchErr.Errors = append(batchErr.Errors, &ChunkError{
+				Index:   i,
+				Domains: chunks[i],
+				Err:     err,
+			})
+		}
+	}
+	if len(batchErr.Errors) == 0 {
+		return errors.Join(auditErrs...)
+	}
+	if len(auditErrs) == 0 {
+		return batchErr
+	}
+	return errors.Join(append([]error{batchErr}, auditErrs...)...)
+}
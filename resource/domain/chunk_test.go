@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/client"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomains_AddAll(t *testing.T) {
+	m := setupRegistryMock(t)
+
+	c := clienttest.NewClient(t)
+	domains := NewDomains(c)
+
+	result, err := domains.AddAll(t.Context(), []string{
+		"1.example.com",
+		"2.example.com",
+		"3.example.com",
+		"4.example.com",
+		"5.example.com",
+	}, option.WithChunkSize(2))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1.example.com", "2.example.com", "3.example.com", "4.example.com", "5.example.com"}, result.Domains)
+	assert.Len(t, result.Outcomes, 5)
+	assert.Equal(t, [][]string{
+		{"1.example.com", "2.example.com"},
+		{"3.example.com", "4.example.com"},
+		{"5.example.com"},
+	}, m.added)
+}
+
+func TestDomains_AddAll_partialFailure(t *testing.T) {
+	m := setupRegistryMock(t)
+
+	c := clienttest.NewClient(t, option.WithRetry(0))
+	domains := NewDomains(c)
+
+	result, err := domains.AddAll(t.Context(), []string{
+		"1.example.com",
+		"2.example.com",
+		"fail-add.example.com",
+		"4.example.com",
+		"5.example.com",
+	}, option.WithChunkSize(2), option.WithConcurrency(3))
+
+	assert.Equal(t, []string{"1.example.com", "2.example.com", "5.example.com"}, result.Domains)
+	assert.Equal(t, []AddStatus{
+		AddStatusAdded,
+		AddStatusAdded,
+		AddStatusFailed,
+		AddStatusFailed,
+		AddStatusAdded,
+	}, lo.Map(result.Outcomes, func(o *AddOutcome, _ int) AddStatus { return o.Status }))
+	assert.Len(t, m.added, 3)
+
+	var batchErr *BatchError
+	if assert.ErrorAs(t, err, &batchErr) {
+		assert.Equal(t, 3, batchErr.Chunks)
+		if assert.Len(t, batchErr.Errors, 1) {
+			assert.Equal(t, 1, batchErr.Errors[0].Index)
+		}
+		assert.Equal(t, []string{"fail-add.example.com", "4.example.com"}, batchErr.Domains())
+	}
+
+	var apiErr *client.APIError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	}
+}
+
+func TestDomains_DeleteAll(t *testing.T) {
+	m := setupRegistryMock(t)
+
+	c := clienttest.NewClient(t)
+	domains := NewDomains(c)
+
+	err := domains.DeleteAll(t.Context(), []string{
+		"1.example.com",
+		"2.example.com",
+		"3.example.com",
+	}, option.WithChunkSize(2), option.WithConcurrency(2))
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, [][]string{
+		{"1.example.com", "2.example.com"},
+		{"3.example.com"},
+	}, m.deleted)
+}
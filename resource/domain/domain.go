@@ -3,7 +3,11 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"time"
 
+	"github.com/morisawa-inc/morisawafonts-webfont-go/audit"
 	"github.com/morisawa-inc/morisawafonts-webfont-go/client"
 	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
 	"github.com/morisawa-inc/morisawafonts-webfont-go/pager"
@@ -12,13 +16,16 @@ import (
 // Domains provides domain management operations.
 type Domains struct {
 	client *client.Client
+	cache  *Cache
 }
 
 // NewDomains creates a new Domains instance.
 func NewDomains(c *client.Client) *Domains {
-	return &Domains{
+	d := &Domains{
 		client: c,
 	}
+	d.cache = NewCache(d)
+	return d
 }
 
 // List returns a paginated list of domains.
@@ -30,10 +37,41 @@ func (d *Domains) List(
 }
 
 // Add adds the specified domains.
+// The call is recorded to the sink set by option.WithAuditSink, if any;
+// when only recording fails, an *audit.Error is returned along with the result of the successful call.
+// The result describes the outcome of each requested domain in AddResult.Outcomes.
+// As the API echoes the domains that were already registered, the registered domains are listed
+// before the request to tell them apart.
+// The domains are normalized and validated before the request unless disabled by
+// option.WithDomainNormalization.
 func (d *Domains) Add(
 	ctx context.Context,
 	domains []string,
 	options ...option.Option,
+) (*AddResult, error) {
+	requested := domains
+	domains, err := d.normalize(domains, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := d.LoadSet(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := d.add(ctx, domains, options...)
+	if result != nil {
+		result.Outcomes = outcomes(requested, result, before)
+	}
+	return result, err
+}
+
+// add registers the normalized domains without computing the outcomes.
+func (d *Domains) add(
+	ctx context.Context,
+	domains []string,
+	options ...option.Option,
 ) (*AddResult, error) {
 	body := map[string]any{
 		"domains": domains,
@@ -41,21 +79,113 @@ func (d *Domains) Add(
 
 	var result AddResult
 	err := d.client.Post(ctx, "/domains", body, &result, options...)
+	auditErr := d.audit(ctx, audit.OperationAdd, domains, &result, err, options...)
 	if err != nil {
-		return nil, err
+		return nil, joinAuditError(err, auditErr)
 	}
-	return &result, nil
+	d.cache.Invalidate()
+	return &result, auditErr
 }
 
 // Delete removes the specified domains.
+// Domains protected by option.WithProtectedDomains are refused with a *ProtectedDomainError
+// before any request unless option.WithOverrideProtection is passed.
+// The call is recorded to the sink set by option.WithAuditSink, if any;
+// when only recording fails, an *audit.Error is returned although the domains were deleted.
+// The domains are normalized and validated before the request unless disabled by
+// option.WithDomainNormalization.
 func (d *Domains) Delete(
 	ctx context.Context,
 	domains []string,
 	options ...option.Option,
 ) error {
+	domains, err := d.normalize(domains, options...)
+	if err != nil {
+		return err
+	}
+	if err := d.checkProtected(domains, options...); err != nil {
+		return err
+	}
+
 	body := map[string]any{
 		"domains": domains,
 	}
 
-	return d.client.Delete(ctx, "/domains", body, options...)
+	err = d.client.Delete(ctx, "/domains", body, options...)
+	auditErr := d.audit(ctx, audit.OperationDelete, domains, nil, err, options...)
+	if err != nil {
+		return joinAuditError(err, auditErr)
+	}
+	d.cache.Invalidate()
+	return auditErr
+}
+
+// audit writes the record of the call to the audit sink, if any.
+func (d *Domains) audit(
+	ctx context.Context,
+	operation audit.Operation,
+	domains []string,
+	response any,
+	err error,
+	options ...option.Option,
+) error {
+	o := d.client.Options(options...)
+	if o.AuditSink == nil {
+		return nil
+	}
+
+	record := &audit.Record{
+		Time:      time.Now(),
+		Actor:     audit.ActorFromContext(ctx),
+		ProjectID: o.ProjectID,
+		Operation: operation,
+		Domains:   domains,
+		Outcome:   audit.OutcomeSuccess,
+	}
+	if err != nil {
+		record.Outcome = audit.OutcomeFailure
+		record.Error = err.Error()
+	} else {
+		record.Response = response
+	}
+
+	if err := o.AuditSink.Write(ctx, record); err != nil {
+		return &audit.Error{Record: record, Err: err}
+	}
+	return nil
+}
+
+func joinAuditError(err, auditErr error) error {
+	if auditErr == nil {
+		return err
+	}
+	return errors.Join(err, auditErr)
+}
+
+// auditOnly returns true if err only consists of *audit.Error,
+// which means that the requests themselves succeeded.
+func auditOnly(err error) bool {
+	switch err := err.(type) {
+	case *audit.Error:
+		return true
+	case interface{ Unwrap() []error }:
+		errs := err.Unwrap()
+		return len(errs) > 0 && !slices.ContainsFunc(errs, func(err error) bool { return !auditOnly(err) })
+	}
+	return false
+}
+
+// requestError returns err unless it only reports audit failures.
+func requestError(err error) error {
+	if auditOnly(err) {
+		return nil
+	}
+	return err
+}
+
+func (d *Domains) normalize(domains []string, options ...option.Option) ([]string, error) {
+	if d.client.Options(options...).DisableDomainNormalization {
+		return domains, nil
+	}
+	return NormalizeAll(domains)
 }
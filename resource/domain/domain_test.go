@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -8,7 +9,9 @@ import (
 	"testing"
 
 	"github.com/jarcoal/httpmock"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/audit"
 	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
 	"github.com/morisawa-inc/morisawafonts-webfont-go/pager"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
@@ -84,6 +87,7 @@ func TestDomains_List(t *testing.T) {
 
 func TestDomains_Add(t *testing.T) {
 	httpmock.Activate(t)
+	registerListMock()
 	httpmock.RegisterResponder(
 		http.MethodPost,
 		"https://api.morisawafonts.com/webfont/v1/domains",
@@ -104,6 +108,10 @@ func TestDomains_Add(t *testing.T) {
 
 	assert.Equal(t, &AddResult{
 		Domains: []string{"example.com", "example.net"},
+		Outcomes: []*AddOutcome{
+			{Requested: "example.com", Registered: "example.com", Status: AddStatusAdded},
+			{Requested: "example.net", Registered: "example.net", Status: AddStatusAdded},
+		},
 	}, result)
 	assert.NoError(t, err)
 }
@@ -128,3 +136,235 @@ func TestDomains_Delete(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestDomains_Add_normalize(t *testing.T) {
+	httpmock.Activate(t)
+	registerListMock()
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		"https://api.morisawafonts.com/webfont/v1/domains",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			assert.JSONEq(t, `{"domains": ["example.com", "example.net"]}`, string(body))
+
+			return httpmock.NewJsonResponse(http.StatusOK, &AddResult{
+				Domains: []string{"example.com", "example.net"},
+			})
+		},
+	)
+
+	c := clienttest.NewClient(t)
+	domains := NewDomains(c)
+
+	_, err := domains.Add(t.Context(), []string{"https://Example.com/path", "example.com.", "example.net:443"})
+
+	assert.NoError(t, err)
+}
+
+func TestDomains_Add_outcomes(t *testing.T) {
+	httpmock.Activate(t)
+	registerListMock("example.net")
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		"https://api.morisawafonts.com/webfont/v1/domains",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			assert.JSONEq(t, `{"domains": ["example.com", "example.net", "example.org", "xn--r8jz45g.xn--zckzah"]}`, string(body))
+
+			return httpmock.NewJsonResponse(http.StatusOK, &AddResult{
+				Domains: []string{"example.com", "example.net", "xn--r8jz45g.xn--zckzah"},
+			})
+		},
+	)
+
+	c := clienttest.NewClient(t)
+	domains := NewDomains(c)
+
+	result, err := domains.Add(t.Context(), []string{
+		"example.com",
+		"Example.com",
+		"example.net",
+		"example.org",
+		"例え.テスト",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*AddOutcome{
+		{Requested: "example.com", Registered: "example.com", Status: AddStatusAdded},
+		{Requested: "Example.com", Registered: "example.com", Status: AddStatusDuplicate},
+		{Requested: "example.net", Registered: "example.net", Status: AddStatusExisting},
+		{Requested: "example.org", Status: AddStatusMissing},
+		{Requested: "例え.テスト", Registered: "xn--r8jz45g.xn--zckzah", Status: AddStatusNormalized},
+	}, result.Outcomes)
+}
+
+func TestDomains_Add_invalid(t *testing.T) {
+	httpmock.Activate(t)
+
+	c := clienttest.NewClient(t)
+	domains := NewDomains(c)
+
+	result, err := domains.Add(t.Context(), []string{"example.com", "a_b.com", "-a.com"})
+
+	assert.Nil(t, result)
+
+	var validationErr *ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Len(t, validationErr.Errors, 2)
+	}
+	assert.Zero(t, httpmock.GetTotalCallCount())
+}
+
+func TestDomains_Delete_withoutNormalization(t *testing.T) {
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodDelete,
+		"https://api.morisawafonts.com/webfont/v1/domains",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			assert.JSONEq(t, `{"domains": ["Example.com"]}`, string(body))
+
+			return httpmock.NewBytesResponse(http.StatusNoContent, nil), nil
+		},
+	)
+
+	c := clienttest.NewClient(t)
+	domains := NewDomains(c)
+
+	err := domains.Delete(t.Context(), []string{"Example.com"}, option.WithDomainNormalization(false))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestDomains_audit(t *testing.T) {
+	httpmock.Activate(t)
+	registerListMock()
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		"https://api.morisawafonts.com/webfont/v1/domains",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, &AddResult{
+			Domains: []string{"example.com"},
+		}),
+	)
+	httpmock.RegisterResponder(
+		http.MethodDelete,
+		"https://api.morisawafonts.com/webfont/v1/domains",
+		httpmock.NewJsonResponderOrPanic(http.StatusNotFound, map[string]string{"message": "not found"}),
+	)
+
+	var records []*audit.Record
+	sink := audit.SinkFunc(func(_ context.Context, record *audit.Record) error {
+		records = append(records, record)
+		return nil
+	})
+
+	c := clienttest.NewClient(t, option.WithAuditSink(sink), option.WithProjectID("project"), option.WithRetry(0))
+	domains := NewDomains(c)
+	ctx := audit.WithActor(t.Context(), "alice")
+
+	_, err := domains.Add(ctx, []string{"Example.com"})
+	assert.NoError(t, err)
+
+	err = domains.Delete(ctx, []string{"example.net"})
+	assert.Error(t, err)
+
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, "alice", records[0].Actor)
+		assert.Equal(t, "project", records[0].ProjectID)
+		assert.Equal(t, audit.OperationAdd, records[0].Operation)
+		assert.Equal(t, []string{"example.com"}, records[0].Domains)
+		assert.Equal(t, audit.OutcomeSuccess, records[0].Outcome)
+		assert.Equal(t, []string{"example.com"}, records[0].Response.(*AddResult).Domains)
+
+		assert.Equal(t, audit.OperationDelete, records[1].Operation)
+		assert.Equal(t, audit.OutcomeFailure, records[1].Outcome)
+		assert.Contains(t, records[1].Error, "not found")
+	}
+}
+
+func TestDomains_audit_error(t *testing.T) {
+	httpmock.Activate(t)
+	registerListMock()
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		"https://api.morisawafonts.com/webfont/v1/domains",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, &AddResult{
+			Domains: []string{"example.com"},
+		}),
+	)
+
+	sinkErr := errors.New("disk full")
+	sink := audit.SinkFunc(func(_ context.Context, _ *audit.Record) error {
+		return sinkErr
+	})
+
+	c := clienttest.NewClient(t, option.WithAuditSink(sink))
+	domains := NewDomains(c)
+
+	result, err := domains.Add(t.Context(), []string{"example.com"})
+
+	var auditErr *audit.Error
+	assert.ErrorAs(t, err, &auditErr)
+	assert.ErrorIs(t, err, sinkErr)
+	assert.Equal(t, audit.OperationAdd, auditErr.Record.Operation)
+	assert.Equal(t, []string{"example.com"}, result.Domains)
+}
+
+func TestDomains_auditFailureIsNotRequestFailure(t *testing.T) {
+	sinkErr := errors.New("disk full")
+	sink := audit.SinkFunc(func(_ context.Context, _ *audit.Record) error {
+		return sinkErr
+	})
+
+	t.Run("AddAll", func(t *testing.T) {
+		setupRegistryMock(t)
+		c := clienttest.NewClient(t, option.WithAuditSink(sink), option.WithChunkSize(1))
+		domains := NewDomains(c)
+
+		result, err := domains.AddAll(t.Context(), []string{"a.example.com", "b.example.com"})
+
+		var batchErr *BatchError
+		assert.False(t, errors.As(err, &batchErr))
+		assert.ErrorIs(t, err, sinkErr)
+		assert.Equal(t, []string{"a.example.com", "b.example.com"}, result.Domains)
+		for _, outcome := range result.Outcomes {
+			assert.Equal(t, AddStatusAdded, outcome.Status)
+		}
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		setupRegistryMock(t, "old.example.com")
+		c := clienttest.NewClient(t, option.WithAuditSink(sink))
+		domains := NewDomains(c)
+
+		result, err := domains.Apply(t.Context(), &Plan{
+			Add:    []string{"new.example.com"},
+			Delete: []string{"old.example.com"},
+		})
+
+		var auditErr *audit.Error
+		assert.ErrorAs(t, err, &auditErr)
+		assert.Equal(t, &ApplyResult{
+			Added:   []string{"new.example.com"},
+			Deleted: []string{"old.example.com"},
+		}, result)
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		setupRegistryMock(t, "old.example.com")
+		c := clienttest.NewClient(t, option.WithAuditSink(sink))
+		domains := NewDomains(c)
+
+		result, err := domains.Replace(t.Context(), []string{"old.example.com"}, []string{"new.example.com"})
+
+		var auditErr *audit.Error
+		assert.ErrorAs(t, err, &auditErr)
+		assert.Equal(t, &ReplaceResult{
+			Added:        []string{"new.example.com"},
+			Deleted:      []string{"old.example.com"},
+			Registered:   []string{"new.example.com"},
+			Unregistered: []string{"old.example.com"},
+		}, result)
+	})
+}
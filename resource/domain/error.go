@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrInvalidDomain is returned when a domain is not valid.
+	ErrInvalidDomain = errors.New("invalid domain")
+	// ErrProtectedDomain is returned when a protected domain would be deleted.
+	ErrProtectedDomain = errors.New("protected domain")
+
+	_ error = (*InvalidDomainError)(nil)
+	_ error = (*ValidationError)(nil)
+	_ error = (*DeletionLimitError)(nil)
+	_ error = (*ChunkError)(nil)
+	_ error = (*BatchError)(nil)
+	_ error = (*ProtectedDomainError)(nil)
+)
+
+// InvalidDomainError represents a domain that failed validation.
+type InvalidDomainError struct {
+	Domain string
+	Reason string
+}
+
+// Error returns the error message for the InvalidDomainError.
+func (err *InvalidDomainError) Error() string {
+	return fmt.Sprintf("invalid domain %q: %s", err.Domain, err.Reason)
+}
+
+// Unwrap returns ErrInvalidDomain.
+func (err *InvalidDomainError) Unwrap() error {
+	return ErrInvalidDomain
+}
+
+// ValidationError reports all invalid domains found in a single input.
+type ValidationError struct {
+	Errors []*InvalidDomainError
+}
+
+// Error returns the error message for the ValidationError.
+func (err *ValidationError) Error() string {
+	messages := make([]string, len(err.Errors))
+	for i, e := range err.Errors {
+		messages[i] = e.Error()
+	}
+	return fmt.Sprintf("domain validation failed: %s", strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors of the invalid domains.
+func (err *ValidationError) Unwrap() []error {
+	errs := make([]error, len(err.Errors))
+	for i, e := range err.Errors {
+		errs[i] = e
+	}
+	return errs
+}
+
+// DeletionLimitError is returned when a plan deletes more domains than allowed by
+// option.WithMaxDeletions.
+type DeletionLimitError struct {
+	Count int
+	Limit int
+}
+
+// Error returns the error message for the DeletionLimitError.
+func (err *DeletionLimitError) Error() string {
+	return fmt.Sprintf("plan deletes %d domains, exceeding the limit of %d", err.Count, err.Limit)
+}
+
+// ChunkError represents a failed request for a chunk of domains.
+type ChunkError struct {
+	Index   int
+	Domains []string
+	Err     error
+}
+
+// Error returns the error message for the ChunkError.
+func (err *ChunkError) Error() string {
+	return fmt.Sprintf("chunk %d (%d domains): %s", err.Index, len(err.Domains), err.Err)
+}
+
+// Unwrap returns the underlying error of the request.
+func (err *ChunkError) Unwrap() error {
+	return err.Err
+}
+
+// BatchError reports the chunks that failed when domains are added or deleted in chunks.
+// The other chunks were processed successfully.
+type BatchError struct {
+	Chunks int
+	Errors []*ChunkError
+}
+
+// Error returns the error message for the BatchError.
+func (err *BatchError) Error() string {
+	messages := make([]string, len(err.Errors))
+	for i, e := range err.Errors {
+		messages[i] = e.Error()
+	}
+	return fmt.Sprintf("%d of %d chunks failed: %s", len(err.Errors), err.Chunks, strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors of the failed chunks.
+func (err *BatchError) Unwrap() []error {
+	errs := make([]error, len(err.Errors))
+	for i, e := range err.Errors {
+		errs[i] = e
+	}
+	return errs
+}
+
+// Domains returns the domains of the failed chunks.
+func (err *BatchError) Domains() []string {
+	var domains []string
+	for _, e := range err.Errors {
+		domains = append(domains, e.Domains...)
+	}
+	return domains
+}
+
+// ProtectedDomainError is returned when domains protected by option.WithProtectedDomains
+// would be deleted.
+type ProtectedDomainError struct {
+	Domains []string
+}
+
+// Error returns the error message for the ProtectedDomainError.
+func (err *ProtectedDomainError) Error() string {
+	return fmt.Sprintf("%s: refusing to delete %s", ErrProtectedDomain, strings.Join(err.Domains, ", "))
+}
+
+// Unwrap returns ErrProtectedDomain.
+func (err *ProtectedDomainError) Unwrap() error {
+	return ErrProtectedDomain
+}
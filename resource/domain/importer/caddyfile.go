@@ -0,0 +1,84 @@
+package importer
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+func readCaddyfile(r io.Reader) ([]*Host, error) {
+	var (
+		hosts     []*Host
+		depth     int
+		sites     bool
+		braceless bool
+	)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := caddyFields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		// site addresses precede a block at the top level, or start a Caddyfile
+		// with a single site block without braces, whose directives follow at the top level
+		opens := fields[len(fields)-1] == "{"
+		if depth == 0 && !braceless && !isCaddyDirective(fields[0]) && (opens || !sites) {
+			for _, field := range fields {
+				for address := range strings.SplitSeq(field, ",") {
+					if host := caddyHost(address); host != "" {
+						hosts = append(hosts, &Host{Raw: host, Line: line})
+					}
+				}
+			}
+			braceless = !opens
+			sites = true
+		}
+
+		for _, field := range fields {
+			switch field {
+			case "{":
+				depth++
+			case "}":
+				depth = max(depth-1, 0)
+			}
+		}
+	}
+	return hosts, scanner.Err()
+}
+
+// caddyFields splits a Caddyfile line into fields, removing comments.
+func caddyFields(line string) []string {
+	fields := strings.Fields(line)
+	for i, field := range fields {
+		if strings.HasPrefix(field, "#") {
+			return fields[:i]
+		}
+	}
+	return fields
+}
+
+// isCaddyDirective returns true if the field starts a top-level line that is not a site block,
+// such as global options, snippets and imports.
+func isCaddyDirective(field string) bool {
+	return field == "{" || field == "}" || field == "import" || strings.HasPrefix(field, "(")
+}
+
+// caddyHost returns the host of a site address, or empty if it has no host.
+func caddyHost(address string) string {
+	if address == "{" || address == "" {
+		return ""
+	}
+
+	host := address
+	if _, rest, ok := strings.Cut(host, "://"); ok {
+		host = rest
+	}
+	host, _, _ = strings.Cut(host, "/")
+	if strings.HasPrefix(host, ":") || strings.Contains(host, "{") {
+		// port only addresses and placeholders
+		return ""
+	}
+	return address
+}
@@ -0,0 +1,54 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+)
+
+var csvColumns = []string{"domain", "host", "hostname"}
+
+func readCSV(r io.Reader) ([]*Host, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+
+	var (
+		hosts  []*Host
+		column = -1
+	)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// the first record is a header if it names a hostname column
+		if column < 0 {
+			column = 0
+			i := slices.IndexFunc(record, func(name string) bool {
+				return slices.Contains(csvColumns, strings.ToLower(strings.TrimSpace(name)))
+			})
+			if i >= 0 {
+				column = i
+				continue
+			}
+		}
+
+		if column >= len(record) {
+			continue
+		}
+		value := strings.TrimSpace(record[column])
+		if value == "" {
+			continue
+		}
+		line, _ := reader.FieldPos(column)
+		hosts = append(hosts, &Host{Raw: value, Line: line})
+	}
+	return hosts, nil
+}
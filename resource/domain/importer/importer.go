@@ -0,0 +1,187 @@
+// Package importer extracts hostnames from configuration files.
+package importer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/resource/domain"
+)
+
+// Format represents a source format.
+type Format int
+
+const (
+	// Text is a plain text list with hostnames separated by whitespace or commas.
+	// Text after "#" is ignored.
+	Text Format = iota
+	// CSV is a CSV list using the "domain" or "host" column, or the first column.
+	CSV
+	// Nginx is an nginx configuration using server_name directives.
+	Nginx
+	// Caddyfile is a Caddyfile using site addresses.
+	Caddyfile
+	// Ingress is a Kubernetes manifest using the hosts of Ingress resources.
+	Ingress
+)
+
+// Host is a hostname discovered in a source.
+type Host struct {
+	// Name is the normalized hostname.
+	Name string
+	// Raw is the hostname as written in the source.
+	Raw string
+	// Source is the file name, or the name given to Read.
+	Source string
+	// Line is the 1-based line number in the source.
+	Line int
+}
+
+// String returns the hostname with its location.
+func (h *Host) String() string {
+	return fmt.Sprintf("%s:%d: %s", h.Source, h.Line, h.Name)
+}
+
+// Read extracts the hostnames from r in the specified format.
+// The source names the input in the returned hosts and errors.
+// Valid hosts are returned along with an *Error when some hostnames are invalid.
+func Read(r io.Reader, format Format, source string) ([]*Host, error) {
+	var (
+		raws []*Host
+		err  error
+	)
+	switch format {
+	case Text:
+		raws, err = readText(r)
+	case CSV:
+		raws, err = readCSV(r)
+	case Nginx:
+		raws, err = readNginx(r)
+	case Caddyfile:
+		raws, err = readCaddyfile(r)
+	case Ingress:
+		raws, err = readIngress(r)
+	default:
+		err = fmt.Errorf("unknown format: %d", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+
+	hosts := make([]*Host, 0, len(raws))
+	importErr := &Error{}
+	for _, host := range raws {
+		host.Source = source
+
+		name, err := domain.Normalize(host.Raw)
+		if err != nil {
+			importErr.Errors = append(importErr.Errors, &HostError{Host: host, Err: err})
+			continue
+		}
+		host.Name = name
+		hosts = append(hosts, host)
+	}
+
+	if len(importErr.Errors) > 0 {
+		return hosts, importErr
+	}
+	return hosts, nil
+}
+
+// ReadFile extracts the hostnames from the file, detecting the format from the file name.
+func ReadFile(path string) ([]*Host, error) {
+	return ReadFileFormat(path, DetectFormat(path))
+}
+
+// ReadFileFormat extracts the hostnames from the file in the specified format.
+func ReadFileFormat(path string, format Format) ([]*Host, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return Read(f, format, path)
+}
+
+// DetectFormat guesses the format from the file name.
+func DetectFormat(path string) Format {
+	name := strings.ToLower(filepath.Base(path))
+	if strings.HasPrefix(name, "caddyfile") || filepath.Ext(name) == ".caddyfile" {
+		return Caddyfile
+	}
+
+	switch filepath.Ext(name) {
+	case ".yaml", ".yml":
+		return Ingress
+	case ".conf":
+		return Nginx
+	case ".csv":
+		return CSV
+	}
+	return Text
+}
+
+// Domains returns the unique hostnames of the hosts in order of first appearance.
+// The result can be passed to domain.Domains.AddAll or domain.Domains.Plan.
+func Domains(hosts []*Host) []string {
+	seen := map[string]struct{}{}
+	var domains []string
+	for _, host := range hosts {
+		if _, ok := seen[host.Name]; ok {
+			continue
+		}
+		seen[host.Name] = struct{}{}
+		domains = append(domains, host.Name)
+	}
+	return domains
+}
+
+var (
+	_ error = (*HostError)(nil)
+	_ error = (*Error)(nil)
+)
+
+// HostError represents an invalid hostname found in a source.
+type HostError struct {
+	Host *Host
+	Err  error
+}
+
+// Error returns the error message for the HostError.
+func (err *HostError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", err.Host.Source, err.Host.Line, err.Err)
+}
+
+// Unwrap returns the validation error of the hostname.
+func (err *HostError) Unwrap() error {
+	return err.Err
+}
+
+// Error reports all invalid hostnames found in a source.
+type Error struct {
+	Errors []*HostError
+}
+
+// Error returns the error message for the Error.
+func (err *Error) Error() string {
+	messages := make([]string, len(err.Errors))
+	for i, e := range err.Errors {
+		messages[i] = e.Error()
+	}
+	return fmt.Sprintf("invalid hosts: %s", strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors of the invalid hostnames.
+func (err *Error) Unwrap() []error {
+	errs := make([]error, len(err.Errors))
+	for i, e := range err.Errors {
+		errs[i] = e
+	}
+	return errs
+}
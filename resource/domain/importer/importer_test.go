@@ -0,0 +1,273 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/resource/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+type location struct {
+	Name string
+	Line int
+}
+
+func locations(hosts []*Host) []location {
+	locations := make([]location, len(hosts))
+	for i, host := range hosts {
+		locations[i] = location{host.Name, host.Line}
+	}
+	return locations
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+		want   []location
+	}{
+		{
+			"text",
+			Text,
+			`# registered domains
+example.com
+Example.net, https://www.example.org/path  # trailing comment
+
+例え.テスト`,
+			[]location{
+				{"example.com", 2},
+				{"example.net", 3},
+				{"www.example.org", 3},
+				{"xn--r8jz45g.xn--zckzah", 5},
+			},
+		},
+		{
+			"csv with header",
+			CSV,
+			`name,Domain
+site a,example.com
+site b,"www.example.net"
+site c,
+`,
+			[]location{
+				{"example.com", 2},
+				{"www.example.net", 3},
+			},
+		},
+		{
+			"csv without header",
+			CSV,
+			`example.com,site a
+example.net,site b
+`,
+			[]location{
+				{"example.com", 1},
+				{"example.net", 2},
+			},
+		},
+		{
+			"nginx",
+			Nginx,
+			`http {
+    server {
+        listen 80 default_server;
+        server_name _;
+    }
+    server {
+        server_name example.com www.example.com; # comment server_name ignored.example.com;
+        location / {
+            root /var/www;
+        }
+    }
+    server {
+        server_name
+            ".example.net"
+            ~^(?<sub>.+)\.example\.org$;
+    }
+}`,
+			[]location{
+				{"example.com", 7},
+				{"www.example.com", 7},
+				{"example.net", 14},
+				{"*.example.net", 14},
+			},
+		},
+		{
+			"caddyfile",
+			Caddyfile,
+			`{
+	email admin@example.com
+}
+
+(common) {
+	encode gzip
+}
+
+example.com, www.example.com {
+	import common
+	reverse_proxy localhost:8080
+}
+
+https://example.net:8443 {
+	respond "hello"
+}
+
+:9000 {
+	respond "metrics"
+}
+
+*.example.org { # wildcard
+	tls {
+		dns cloudflare
+	}
+}`,
+			[]location{
+				{"example.com", 9},
+				{"www.example.com", 9},
+				{"example.net", 14},
+				{"*.example.org", 22},
+			},
+		},
+		{
+			"caddyfile without braces",
+			Caddyfile,
+			`example.com
+reverse_proxy localhost:8080
+`,
+			[]location{
+				{"example.com", 1},
+			},
+		},
+		{
+			"caddyfile without braces with blocks",
+			Caddyfile,
+			`{
+	email admin@example.com
+}
+
+example.com www.example.com
+reverse_proxy localhost:8080
+handle /api {
+	reverse_proxy localhost:9000
+}
+route {
+	file_server
+}
+`,
+			[]location{
+				{"example.com", 5},
+				{"www.example.com", 5},
+			},
+		},
+		{
+			"ingress",
+			Ingress,
+			`apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web
+spec:
+  tls:
+    - hosts:
+        - www.example.com
+      secretName: web-tls
+  rules:
+    - host: www.example.com
+      http:
+        paths:
+          - path: /
+            pathType: Prefix
+            backend:
+              service:
+                name: web
+                port:
+                  number: 80
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+---
+apiVersion: v1
+kind: List
+items:
+  - apiVersion: networking.k8s.io/v1
+    kind: Ingress
+    spec:
+      rules:
+        - host: "*.example.net"
+        - http: {}
+`,
+			[]location{
+				{"www.example.com", 11},
+				{"www.example.com", 8},
+				{"*.example.net", 34},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			hosts, err := Read(strings.NewReader(tt.input), tt.format, "source")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, locations(hosts))
+			for _, host := range hosts {
+				assert.Equal(t, "source", host.Source)
+			}
+		})
+	}
+}
+
+func TestRead_invalid(t *testing.T) {
+	hosts, err := Read(strings.NewReader("example.com\na_b.example.com\nexample..net\n"), Text, "domains.txt")
+
+	assert.Equal(t, []location{{"example.com", 1}}, locations(hosts))
+
+	var importErr *Error
+	if assert.ErrorAs(t, err, &importErr) && assert.Len(t, importErr.Errors, 2) {
+		assert.Equal(t, 2, importErr.Errors[0].Host.Line)
+		assert.Equal(t, "a_b.example.com", importErr.Errors[0].Host.Raw)
+		assert.Equal(t, 3, importErr.Errors[1].Host.Line)
+	}
+	assert.ErrorIs(t, err, domain.ErrInvalidDomain)
+	assert.ErrorContains(t, err, "domains.txt:2: ")
+}
+
+func TestReadFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "site.conf")
+	err := os.WriteFile(path, []byte("server {\n  server_name example.com;\n}\n"), 0o600)
+	assert.NoError(t, err)
+
+	hosts, err := ReadFile(path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*Host{
+		{Name: "example.com", Raw: "example.com", Source: path, Line: 2},
+	}, hosts)
+}
+
+func TestDetectFormat(t *testing.T) {
+	assert.Equal(t, Ingress, DetectFormat("k8s/ingress.yaml"))
+	assert.Equal(t, Ingress, DetectFormat("ingress.yml"))
+	assert.Equal(t, Nginx, DetectFormat("/etc/nginx/conf.d/site.conf"))
+	assert.Equal(t, Caddyfile, DetectFormat("/etc/caddy/Caddyfile"))
+	assert.Equal(t, CSV, DetectFormat("domains.csv"))
+	assert.Equal(t, Text, DetectFormat("domains.txt"))
+}
+
+func TestDomains(t *testing.T) {
+	hosts := []*Host{
+		{Name: "example.com"},
+		{Name: "example.net"},
+		{Name: "example.com"},
+	}
+
+	assert.Equal(t, []string{"example.com", "example.net"}, Domains(hosts))
+}
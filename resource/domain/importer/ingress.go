@@ -0,0 +1,82 @@
+package importer
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+func readIngress(r io.Reader) ([]*Host, error) {
+	var hosts []*Host
+
+	decoder := yaml.NewDecoder(r)
+	for {
+		var document yaml.Node
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, node := range document.Content {
+			hosts = appendIngressHosts(hosts, node)
+		}
+	}
+	return hosts, nil
+}
+
+// appendIngressHosts appends the hosts of the Ingress resource, or of the Ingress
+// resources in the list.
+func appendIngressHosts(hosts []*Host, node *yaml.Node) []*Host {
+	kind := field(node, "kind")
+	if kind == nil {
+		return hosts
+	}
+
+	switch {
+	case kind.Value == "Ingress":
+		spec := field(node, "spec")
+		for _, rule := range items(field(spec, "rules")) {
+			if host := field(rule, "host"); host != nil && host.Value != "" {
+				hosts = append(hosts, &Host{Raw: host.Value, Line: host.Line})
+			}
+		}
+		for _, tls := range items(field(spec, "tls")) {
+			for _, host := range items(field(tls, "hosts")) {
+				if host.Value != "" {
+					hosts = append(hosts, &Host{Raw: host.Value, Line: host.Line})
+				}
+			}
+		}
+	case strings.HasSuffix(kind.Value, "List"):
+		for _, item := range items(field(node, "items")) {
+			hosts = appendIngressHosts(hosts, item)
+		}
+	}
+	return hosts
+}
+
+// field returns the value of the key in the mapping node, or nil if not found.
+func field(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// items returns the items of the sequence node, or nil if it is not a sequence.
+func items(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return node.Content
+}
@@ -0,0 +1,105 @@
+package importer
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+type token struct {
+	text   string
+	line   int
+	quoted bool
+}
+
+// tokenizeNginx splits an nginx configuration into tokens.
+// Comments are removed and ";", "{" and "}" are returned as separate tokens.
+func tokenizeNginx(r io.Reader) ([]token, error) {
+	var (
+		tokens  []token
+		current strings.Builder
+		quote   rune
+		quoted  bool
+		start   int
+	)
+	flush := func() {
+		if current.Len() > 0 || quoted {
+			tokens = append(tokens, token{text: current.String(), line: start, quoted: quoted})
+		}
+		current.Reset()
+		quoted = false
+	}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+	chars:
+		for _, c := range scanner.Text() {
+			if quote != 0 {
+				if c == quote {
+					quote = 0
+				} else {
+					current.WriteRune(c)
+				}
+				continue
+			}
+
+			switch c {
+			case '#':
+				break chars
+			case '"', '\'':
+				if current.Len() == 0 {
+					start = line
+				}
+				quote = c
+				quoted = true
+			case ';', '{', '}':
+				flush()
+				tokens = append(tokens, token{text: string(c), line: line})
+			case ' ', '\t':
+				flush()
+			default:
+				if current.Len() == 0 && !quoted {
+					start = line
+				}
+				current.WriteRune(c)
+			}
+		}
+		if quote == 0 {
+			flush()
+		}
+	}
+	return tokens, scanner.Err()
+}
+
+func readNginx(r io.Reader) ([]*Host, error) {
+	tokens, err := tokenizeNginx(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var hosts []*Host
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].quoted || tokens[i].text != "server_name" {
+			continue
+		}
+
+		for i++; i < len(tokens) && (tokens[i].quoted || tokens[i].text != ";"); i++ {
+			name := tokens[i].text
+			switch {
+			case name == "" || name == "_":
+				// catch-all server
+			case strings.HasPrefix(name, "~") || strings.Contains(name, "$"):
+				// regular expressions and variables cannot be resolved
+			case strings.HasPrefix(name, "."):
+				// ".example.com" matches both the domain and its subdomains
+				hosts = append(hosts,
+					&Host{Raw: name[1:], Line: tokens[i].line},
+					&Host{Raw: "*" + name, Line: tokens[i].line},
+				)
+			default:
+				hosts = append(hosts, &Host{Raw: name, Line: tokens[i].line})
+			}
+		}
+	}
+	return hosts, nil
+}
@@ -0,0 +1,23 @@
+package importer
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+func readText(r io.Reader) ([]*Host, error) {
+	var hosts []*Host
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.FieldsFunc(text, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
+		for _, field := range fields {
+			hosts = append(hosts, &Host{Raw: field, Line: line})
+		}
+	}
+	return hosts, scanner.Err()
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/pager"
+)
+
+// registryMock is a fake of the /domains endpoints that keeps the registered domains.
+// Like the API, adding echoes every requested domain, including those already registered.
+// Requests containing domains starting with "fail-add" or "fail-delete" fail.
+type registryMock struct {
+	mu         sync.Mutex
+	registered []string
+	added      [][]string
+	deleted    [][]string
+}
+
+// setupRegistryMock registers the responders of a registry holding the domains.
+func setupRegistryMock(t *testing.T, registered ...string) *registryMock {
+	t.Helper()
+
+	m := &registryMock{registered: slices.Clone(registered)}
+
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/domains",
+		func(req *http.Request) (*http.Response, error) {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+
+			return httpmock.NewJsonResponse(http.StatusOK, pager.Page[string, *ListMetadata]{
+				Result: slices.Clone(m.registered),
+				Meta:   &ListMetadata{ProjectID: "project"},
+			})
+		},
+	)
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		"https://api.morisawafonts.com/webfont/v1/domains",
+		func(req *http.Request) (*http.Response, error) {
+			domains := readDomains(req)
+
+			m.mu.Lock()
+			defer m.mu.Unlock()
+
+			m.added = append(m.added, domains)
+			if hasPrefix(domains, "fail-add") {
+				return httpmock.NewJsonResponse(http.StatusInternalServerError, map[string]string{"message": "add failed"})
+			}
+			for _, domain := range domains {
+				if !slices.Contains(m.registered, domain) {
+					m.registered = append(m.registered, domain)
+				}
+			}
+			return httpmock.NewJsonResponse(http.StatusOK, &AddResult{Domains: domains})
+		},
+	)
+	httpmock.RegisterResponder(
+		http.MethodDelete,
+		"https://api.morisawafonts.com/webfont/v1/domains",
+		func(req *http.Request) (*http.Response, error) {
+			domains := readDomains(req)
+
+			m.mu.Lock()
+			defer m.mu.Unlock()
+
+			m.deleted = append(m.deleted, domains)
+			if hasPrefix(domains, "fail-delete") {
+				return httpmock.NewJsonResponse(http.StatusInternalServerError, map[string]string{"message": "delete failed"})
+			}
+			m.registered = slices.DeleteFunc(m.registered, func(domain string) bool {
+				return slices.Contains(domains, domain)
+			})
+			return httpmock.NewBytesResponse(http.StatusNoContent, nil), nil
+		},
+	)
+	return m
+}
+
+// registerListMock registers a responder listing the domains, for tests mocking the other endpoints themselves.
+func registerListMock(registered ...string) {
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/domains",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, pager.Page[string, *ListMetadata]{
+			Result: registered,
+			Meta:   &ListMetadata{ProjectID: "project"},
+		}),
+	)
+}
+
+func readDomains(req *http.Request) []string {
+	var body struct {
+		Domains []string `json:"domains"`
+	}
+	raw, _ := io.ReadAll(req.Body)
+	_ = json.Unmarshal(raw, &body)
+	return body.Domains
+}
+
+func hasPrefix(domains []string, prefix string) bool {
+	return slices.ContainsFunc(domains, func(domain string) bool {
+		return strings.HasPrefix(domain, prefix)
+	})
+}
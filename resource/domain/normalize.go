@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+const (
+	// WildcardPrefix is the prefix of a wildcard domain that matches all subdomains.
+	WildcardPrefix = "*."
+
+	maxDomainLength = 253
+	maxLabelLength  = 63
+)
+
+// Normalize converts the input to the form registered to the API.
+// It strips schemes, user info, paths, ports and trailing dots, lowercases
+// and converts internationalized domain names to punycode, then validates the result.
+func Normalize(input string) (string, error) {
+	host := strings.TrimSpace(input)
+
+	if strings.Contains(host, "://") {
+		u, err := url.Parse(host)
+		if err != nil {
+			return "", &InvalidDomainError{Domain: input, Reason: "malformed URL"}
+		}
+		host = u.Host
+	} else {
+		host, _, _ = strings.Cut(host, "/")
+		host, _, _ = strings.Cut(host, "?")
+		host, _, _ = strings.Cut(host, "#")
+	}
+	if i := strings.LastIndex(host, "@"); i >= 0 {
+		host = host[i+1:]
+	}
+	if strings.Contains(host, ":") {
+		h, _, err := net.SplitHostPort(host)
+		if err != nil {
+			return "", &InvalidDomainError{Domain: input, Reason: "malformed port"}
+		}
+		host = h
+	}
+	host = strings.TrimSuffix(host, ".")
+
+	wildcard := strings.HasPrefix(host, WildcardPrefix)
+	if wildcard {
+		host = strings.TrimPrefix(host, WildcardPrefix)
+	}
+
+	host, err := idna.Lookup.ToASCII(strings.ToLower(host))
+	if wildcard {
+		host = WildcardPrefix + host
+	}
+	if reason := validate(host); reason != "" {
+		return "", &InvalidDomainError{Domain: input, Reason: reason}
+	}
+	if err != nil {
+		return "", &InvalidDomainError{Domain: input, Reason: "invalid internationalized domain name"}
+	}
+	return host, nil
+}
+
+// NormalizeAll normalizes the inputs and removes duplicates, keeping the order of first appearance.
+// All invalid inputs are reported together in a *ValidationError.
+func NormalizeAll(inputs []string) ([]string, error) {
+	domains := make([]string, 0, len(inputs))
+	seen := map[string]struct{}{}
+
+	var errs []*InvalidDomainError
+	for _, input := range inputs {
+		domain, err := Normalize(input)
+		var invalidErr *InvalidDomainError
+		if errors.As(err, &invalidErr) {
+			errs = append(errs, invalidErr)
+			continue
+		}
+		if _, ok := seen[domain]; ok {
+			continue
+		}
+		seen[domain] = struct{}{}
+		domains = append(domains, domain)
+	}
+
+	if len(errs) > 0 {
+		return nil, &ValidationError{Errors: errs}
+	}
+	return domains, nil
+}
+
+// Validate reports whether the domain is in the normalized form accepted by the API.
+// A wildcard is allowed only as the leftmost label, e.g. "*.example.com".
+func Validate(domain string) error {
+	if reason := validate(domain); reason != "" {
+		return &InvalidDomainError{Domain: domain, Reason: reason}
+	}
+	return nil
+}
+
+func validate(domain string) string {
+	host := strings.TrimPrefix(domain, WildcardPrefix)
+	if host == "" {
+		return "empty"
+	}
+	if len(domain) > maxDomainLength {
+		return fmt.Sprintf("longer than %d characters", maxDomainLength)
+	}
+
+	for label := range strings.SplitSeq(host, ".") {
+		switch {
+		case label == "":
+			return "empty label"
+		case label == "*":
+			return "wildcard is allowed only as the leftmost label of a domain"
+		case len(label) > maxLabelLength:
+			return fmt.Sprintf("label %q is longer than %d characters", label, maxLabelLength)
+		case label[0] == '-' || label[len(label)-1] == '-':
+			return fmt.Sprintf("label %q starts or ends with a hyphen", label)
+		}
+		for _, r := range label {
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+				return fmt.Sprintf("label %q contains invalid character %q", label, r)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+package domain
+
+// outcomes computes the outcome of each requested domain by diffing the request against the result.
+// The API echoes every requested domain that is registered, whether or not it was registered before,
+// so the domains registered before the call are told apart by the set loaded before the request.
+func outcomes(requested []string, result *AddResult, before *Set) []*AddOutcome {
+	registered := map[string]string{}
+	for _, domain := range result.Domains {
+		registered[key(domain)] = domain
+	}
+
+	seen := map[string]struct{}{}
+	outcomes := make([]*AddOutcome, len(requested))
+	for i, domain := range requested {
+		k := key(domain)
+		outcome := &AddOutcome{
+			Requested:  domain,
+			Registered: registered[k],
+		}
+
+		_, duplicate := seen[k]
+		seen[k] = struct{}{}
+
+		switch {
+		case duplicate:
+			outcome.Status = AddStatusDuplicate
+		case outcome.Registered == "":
+			outcome.Status = AddStatusMissing
+		case before.Has(outcome.Registered):
+			outcome.Status = AddStatusExisting
+		case outcome.Registered == domain:
+			outcome.Status = AddStatusAdded
+		default:
+			outcome.Status = AddStatusNormalized
+		}
+		outcomes[i] = outcome
+	}
+	return outcomes
+}
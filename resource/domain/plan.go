@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+	"github.com/samber/lo"
+)
+
+// Plan describes the changes that make the registered domains match the desired domains.
+type Plan struct {
+	// Add is the desired domains that are not registered.
+	Add []string
+	// Delete is the registered domains that are not desired.
+	Delete []string
+	// Keep is the registered domains that are desired.
+	Keep []string
+}
+
+// ApplyResult describes the changes actually made by Domains.Apply.
+type ApplyResult struct {
+	Added   []string
+	Deleted []string
+}
+
+// Empty returns true if the plan makes no changes.
+func (p *Plan) Empty() bool {
+	return len(p.Add) == 0 && len(p.Delete) == 0
+}
+
+// String renders the plan as a diff, which can be shown for dry runs.
+func (p *Plan) String() string {
+	var b strings.Builder
+	for _, domain := range p.Add {
+		fmt.Fprintf(&b, "+ %s\n", domain)
+	}
+	for _, domain := range p.Delete {
+		fmt.Fprintf(&b, "- %s\n", domain)
+	}
+	fmt.Fprintf(&b, "%d to add, %d to delete, %d unchanged\n", len(p.Add), len(p.Delete), len(p.Keep))
+	return b.String()
+}
+
+// Plan computes the changes that make the registered domains match the desired domains.
+// The desired domains are normalized unless disabled by option.WithDomainNormalization.
+func (d *Domains) Plan(
+	ctx context.Context,
+	desired []string,
+	options ...option.Option,
+) (*Plan, error) {
+	desired, err := d.normalize(desired, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := lo.SliceToMap(desired, func(domain string) (string, struct{}) {
+		return key(domain), struct{}{}
+	})
+	registered := map[string]struct{}{}
+
+	plan := &Plan{}
+	for item, err := range d.List(nil, options...).Iter(ctx) {
+		if err != nil {
+			return nil, err
+		}
+
+		k := key(item.Value)
+		registered[k] = struct{}{}
+		if _, ok := wanted[k]; ok {
+			plan.Keep = append(plan.Keep, item.Value)
+		} else {
+			plan.Delete = append(plan.Delete, item.Value)
+		}
+	}
+	for _, domain := range desired {
+		if _, ok := registered[key(domain)]; !ok {
+			plan.Add = append(plan.Add, domain)
+		}
+	}
+	return plan, nil
+}
+
+// Apply executes the plan by adding and then deleting domains in chunks of
+// option.WithChunkSize. Domains are not deleted when adding fails.
+// Nothing is changed when the plan exceeds option.WithMaxDeletions or deletes
+// domains protected by option.WithProtectedDomains.
+// The returned result describes the changes made even when an error occurs midway.
+// Failures to record audit records do not stop the plan and are returned as *audit.Error.
+func (d *Domains) Apply(
+	ctx context.Context,
+	plan *Plan,
+	options ...option.Option,
+) (*ApplyResult, error) {
+	o := d.client.Options(options...)
+	if o.MaxDeletions > 0 && len(plan.Delete) > o.MaxDeletions {
+		return nil, &DeletionLimitError{Count: len(plan.Delete), Limit: o.MaxDeletions}
+	}
+	if err := d.checkProtected(plan.Delete, options...); err != nil {
+		return nil, err
+	}
+
+	var (
+		result    = &ApplyResult{}
+		auditErrs error
+	)
+	if len(plan.Add) > 0 {
+		added, err := d.AddAll(ctx, plan.Add, options...)
+		if added != nil {
+			result.Added = added.Domains
+		}
+		if requestError(err) != nil {
+			return result, err
+		}
+		auditErrs = err
+	}
+	if len(plan.Delete) > 0 {
+		err := d.DeleteAll(ctx, plan.Delete, options...)
+		result.Deleted = plan.Delete
+		if requestError(err) != nil {
+			var batchErr *BatchError
+			if errors.As(err, &batchErr) {
+				result.Deleted, _ = lo.Difference(plan.Delete, batchErr.Domains())
+			} else {
+				result.Deleted = nil
+			}
+			return result, errors.Join(auditErrs, err)
+		}
+		auditErrs = errors.Join(auditErrs, err)
+	}
+	return result, auditErrs
+}
+
+// key returns the normalized form of the domain used for comparison.
+func key(domain string) string {
+	normalized, err := Normalize(domain)
+	if err != nil {
+		return domain
+	}
+	return normalized
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomains_Plan(t *testing.T) {
+	setupRegistryMock(t, "a.example.com", "b.example.com", "c.example.com")
+
+	c := clienttest.NewClient(t)
+	domains := NewDomains(c)
+
+	plan, err := domains.Plan(t.Context(), []string{"https://A.example.com/", "d.example.com", "d.example.com."})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Plan{
+		Add:    []string{"d.example.com"},
+		Delete: []string{"b.example.com", "c.example.com"},
+		Keep:   []string{"a.example.com"},
+	}, plan)
+	assert.False(t, plan.Empty())
+	assert.Equal(t, "+ d.example.com\n- b.example.com\n- c.example.com\n1 to add, 2 to delete, 1 unchanged\n", plan.String())
+}
+
+func TestDomains_Apply(t *testing.T) {
+	m := setupRegistryMock(t)
+
+	c := clienttest.NewClient(t)
+	domains := NewDomains(c)
+
+	result, err := domains.Apply(t.Context(), &Plan{
+		Add:    []string{"d.example.com"},
+		Delete: []string{"b.example.com", "c.example.com", "e.example.com"},
+	}, option.WithChunkSize(2))
+
+	assert.NoError(t, err)
+	assert.Equal(t, &ApplyResult{
+		Added:   []string{"d.example.com"},
+		Deleted: []string{"b.example.com", "c.example.com", "e.example.com"},
+	}, result)
+	assert.Equal(t, [][]string{{"b.example.com", "c.example.com"}, {"e.example.com"}}, m.deleted)
+}
+
+func TestDomains_Apply_maxDeletions(t *testing.T) {
+	setupRegistryMock(t)
+
+	c := clienttest.NewClient(t)
+	domains := NewDomains(c)
+
+	result, err := domains.Apply(t.Context(), &Plan{
+		Add:    []string{"d.example.com"},
+		Delete: []string{"b.example.com", "c.example.com"},
+	}, option.WithMaxDeletions(1))
+
+	assert.Nil(t, result)
+
+	var limitErr *DeletionLimitError
+	if assert.ErrorAs(t, err, &limitErr) {
+		assert.Equal(t, 2, limitErr.Count)
+		assert.Equal(t, 1, limitErr.Limit)
+	}
+	assert.Zero(t, httpmock.GetTotalCallCount())
+}
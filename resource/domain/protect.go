@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+)
+
+// checkProtected returns a *ProtectedDomainError if any of the domains is protected
+// by option.WithProtectedDomains, unless overridden by option.WithOverrideProtection.
+func (d *Domains) checkProtected(domains []string, options ...option.Option) error {
+	o := d.client.Options(options...)
+	if o.OverrideProtection || len(o.ProtectedDomains) == 0 {
+		return nil
+	}
+
+	patterns := make([]string, len(o.ProtectedDomains))
+	for i, pattern := range o.ProtectedDomains {
+		patterns[i] = key(pattern)
+	}
+
+	var protected []string
+	for _, domain := range domains {
+		if isProtected(key(domain), patterns) {
+			protected = append(protected, domain)
+		}
+	}
+	if len(protected) > 0 {
+		return &ProtectedDomainError{Domains: protected}
+	}
+	return nil
+}
+
+func isProtected(domain string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if domain == pattern {
+			return true
+		}
+		if parent, ok := strings.CutPrefix(pattern, WildcardPrefix); ok && strings.HasSuffix(domain, "."+parent) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomains_Delete_protected(t *testing.T) {
+	tests := []struct {
+		name          string
+		domains       []string
+		options       []option.Option
+		wantProtected []string
+	}{
+		{
+			"exact",
+			[]string{"Example.com", "example.net"},
+			nil,
+			[]string{"example.com"},
+		},
+		{
+			"wildcard",
+			[]string{"www.example.org", "example.org", "*.example.org"},
+			nil,
+			[]string{"www.example.org", "*.example.org"},
+		},
+		{
+			"subdomain of exact",
+			[]string{"www.example.com"},
+			nil,
+			nil,
+		},
+		{
+			"override",
+			[]string{"example.com"},
+			[]option.Option{option.WithOverrideProtection(true)},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupRegistryMock(t)
+
+			c := clienttest.NewClient(t, option.WithProtectedDomains("example.com", "*.example.org"))
+			domains := NewDomains(c)
+
+			err := domains.Delete(t.Context(), tt.domains, tt.options...)
+
+			if tt.wantProtected == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, httpmock.GetTotalCallCount())
+				return
+			}
+
+			var protectedErr *ProtectedDomainError
+			if assert.ErrorAs(t, err, &protectedErr) {
+				assert.Equal(t, tt.wantProtected, protectedErr.Domains)
+			}
+			assert.ErrorIs(t, err, ErrProtectedDomain)
+			assert.Zero(t, httpmock.GetTotalCallCount())
+		})
+	}
+}
+
+func TestDomains_Apply_protected(t *testing.T) {
+	setupRegistryMock(t)
+
+	c := clienttest.NewClient(t, option.WithProtectedDomains("example.com"))
+	domains := NewDomains(c)
+
+	result, err := domains.Apply(t.Context(), &Plan{
+		Add:    []string{"example.net"},
+		Delete: []string{"example.com"},
+	})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrProtectedDomain)
+	assert.Zero(t, httpmock.GetTotalCallCount())
+}
+
+func TestDomains_DeleteAll_protected(t *testing.T) {
+	setupRegistryMock(t)
+
+	c := clienttest.NewClient(t, option.WithProtectedDomains("example.com"))
+	domains := NewDomains(c)
+
+	err := domains.DeleteAll(t.Context(), []string{"1.example.net", "2.example.net", "example.com"}, option.WithChunkSize(1))
+
+	assert.ErrorIs(t, err, ErrProtectedDomain)
+	assert.Zero(t, httpmock.GetTotalCallCount())
+}
@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+	"github.com/samber/lo"
+)
+
+// ReplaceResult describes the outcome of Domains.Replace.
+type ReplaceResult struct {
+	// Added is the domains newly registered by the replacement,
+	// excluding the domains that were registered before it.
+	Added []string
+	// Deleted is the domains deleted by the replacement.
+	Deleted []string
+	// RolledBack is true if a step failed and compensation was attempted.
+	RolledBack bool
+	// CompensationErrors is the errors of compensating steps that failed,
+	// which leave the registered domains in a mixed state.
+	CompensationErrors []error
+
+	// Registered is the old and new domains that are registered in the final state.
+	Registered []string
+	// Unregistered is the old and new domains that are not registered in the final state.
+	Unregistered []string
+}
+
+// Replace registers the new domains and then deletes the old domains.
+// If a step fails, it attempts to restore the prior set by deleting the newly registered
+// domains and adding back the deleted domains. Domains registered before the call,
+// including the old domains, are never deleted by the compensation. The result reports the final state
+// and any compensation failures, and is returned along with the error of the failed step.
+// Failures to record audit records do not trigger compensation and are returned as *audit.Error.
+func (d *Domains) Replace(
+	ctx context.Context,
+	oldDomains []string,
+	newDomains []string,
+	options ...option.Option,
+) (*ReplaceResult, error) {
+	oldDomains, err := d.normalize(oldDomains, options...)
+	if err != nil {
+		return nil, err
+	}
+	newDomains, err = d.normalize(newDomains, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	deleting, _ := lo.Difference(oldDomains, newDomains)
+	if err := d.checkProtected(deleting, options...); err != nil {
+		return nil, err
+	}
+
+	state := map[string]bool{}
+	for _, domain := range oldDomains {
+		state[domain] = true
+	}
+	for _, domain := range newDomains {
+		if _, ok := state[domain]; !ok {
+			state[domain] = false
+		}
+	}
+	all := slices.Concat(oldDomains, newDomains)
+
+	// the API echoes domains that were already registered, so the domains registered
+	// before the call are captured to never delete them in compensation
+	before, err := d.LoadSet(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+	for _, domain := range newDomains {
+		if before.Has(domain) {
+			state[domain] = true
+		}
+	}
+
+	result := &ReplaceResult{}
+	added, err := d.addAll(ctx, newDomains, before, options...)
+	if added != nil {
+		for _, outcome := range added.Outcomes {
+			switch outcome.Status {
+			case AddStatusAdded, AddStatusNormalized:
+				state[outcome.Registered] = true
+				if !slices.Contains(oldDomains, outcome.Registered) {
+					result.Added = append(result.Added, outcome.Registered)
+				}
+			case AddStatusExisting:
+				state[outcome.Registered] = true
+			}
+		}
+	}
+	if requestError(err) != nil {
+		auditErrs := d.compensate(ctx, result, state, options...)
+		result.report(all, state)
+		return result, joinCompensationErrors(errors.Join(err, auditErrs), result.CompensationErrors)
+	}
+	auditErrs := err
+
+	err = d.DeleteAll(ctx, deleting, options...)
+	for _, domain := range succeeded(deleting, err) {
+		state[domain] = false
+		result.Deleted = append(result.Deleted, domain)
+	}
+	if requestError(err) != nil {
+		compensationAuditErrs := d.compensate(ctx, result, state, options...)
+		result.report(all, state)
+		return result, joinCompensationErrors(errors.Join(auditErrs, err, compensationAuditErrs), result.CompensationErrors)
+	}
+
+	result.report(all, state)
+	return result, errors.Join(auditErrs, err)
+}
+
+// succeeded returns the domains of a chunked operation that did not fail with err.
+func succeeded(domains []string, err error) []string {
+	var batchErr *BatchError
+	switch {
+	case errors.As(err, &batchErr):
+		domains, _ = lo.Difference(domains, batchErr.Domains())
+		return domains
+	case requestError(err) != nil:
+		return nil
+	}
+	return domains
+}
+
+// report sets the final state of the domains to the result.
+func (r *ReplaceResult) report(domains []string, state map[string]bool) {
+	for _, domain := range lo.Uniq(domains) {
+		if state[domain] {
+			r.Registered = append(r.Registered, domain)
+		} else {
+			r.Unregistered = append(r.Unregistered, domain)
+		}
+	}
+}
+
+// compensate adds back the deleted domains and deletes the added domains.
+// Failures to record audit records of the compensating steps are returned.
+func (d *Domains) compensate(
+	ctx context.Context,
+	result *ReplaceResult,
+	state map[string]bool,
+	options ...option.Option,
+) error {
+	result.RolledBack = true
+
+	var auditErrs error
+	if len(result.Deleted) > 0 {
+		restored, err := d.AddAll(ctx, result.Deleted, options...)
+		if restored != nil {
+			for _, outcome := range restored.Outcomes {
+				if outcome.Registered != "" {
+					state[key(outcome.Requested)] = true
+				}
+			}
+		}
+		if requestError(err) != nil {
+			result.CompensationErrors = append(result.CompensationErrors, err)
+		} else {
+			auditErrs = errors.Join(auditErrs, err)
+		}
+	}
+
+	if len(result.Added) > 0 {
+		// the added domains are removed even if protected, to restore the prior set
+		err := d.DeleteAll(ctx, result.Added, append(options, option.WithOverrideProtection(true))...)
+		for _, domain := range succeeded(result.Added, err) {
+			state[domain] = false
+		}
+		if requestError(err) != nil {
+			result.CompensationErrors = append(result.CompensationErrors, err)
+		} else {
+			auditErrs = errors.Join(auditErrs, err)
+		}
+	}
+	return auditErrs
+}
+
+func joinCompensationErrors(err error, errs []error) error {
+	if len(errs) == 0 {
+		return err
+	}
+	return errors.Join(append([]error{err}, errs...)...)
+}
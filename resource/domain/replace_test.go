@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomains_Replace(t *testing.T) {
+	tests := []struct {
+		name                   string
+		registered             []string
+		oldDomains             []string
+		newDomains             []string
+		want                   *ReplaceResult
+		wantErr                bool
+		wantCompensationErrors int
+	}{
+		{
+			"success",
+			[]string{"old.example.com", "keep.example.com"},
+			[]string{"old.example.com", "keep.example.com"},
+			[]string{"new.example.com", "keep.example.com"},
+			&ReplaceResult{
+				Added:        []string{"new.example.com"},
+				Deleted:      []string{"old.example.com"},
+				Registered:   []string{"keep.example.com", "new.example.com"},
+				Unregistered: []string{"old.example.com"},
+			},
+			false,
+			0,
+		},
+		{
+			"add fails",
+			[]string{"old.example.com"},
+			[]string{"old.example.com"},
+			[]string{"new.example.com", "fail-add.example.com"},
+			&ReplaceResult{
+				Added:        []string{"new.example.com"},
+				RolledBack:   true,
+				Registered:   []string{"old.example.com"},
+				Unregistered: []string{"new.example.com", "fail-add.example.com"},
+			},
+			true,
+			0,
+		},
+		{
+			"delete fails",
+			[]string{"old.example.com", "fail-delete.example.com"},
+			[]string{"old.example.com", "fail-delete.example.com"},
+			[]string{"new.example.com"},
+			&ReplaceResult{
+				Added:        []string{"new.example.com"},
+				Deleted:      []string{"old.example.com"},
+				RolledBack:   true,
+				Registered:   []string{"old.example.com", "fail-delete.example.com"},
+				Unregistered: []string{"new.example.com"},
+			},
+			true,
+			0,
+		},
+		{
+			"compensation fails",
+			[]string{"fail-delete.example.com"},
+			[]string{"fail-delete.example.com"},
+			[]string{"fail-delete-new.example.com"},
+			&ReplaceResult{
+				Added:        []string{"fail-delete-new.example.com"},
+				RolledBack:   true,
+				Registered:   []string{"fail-delete.example.com", "fail-delete-new.example.com"},
+				Unregistered: nil,
+			},
+			true,
+			1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupRegistryMock(t, tt.registered...)
+
+			c := clienttest.NewClient(t, option.WithRetry(0), option.WithChunkSize(1))
+			domains := NewDomains(c)
+
+			result, err := domains.Replace(t.Context(), tt.oldDomains, tt.newDomains)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, result.CompensationErrors, tt.wantCompensationErrors)
+			result.CompensationErrors = nil
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func TestDomains_Replace_protected(t *testing.T) {
+	setupRegistryMock(t, "old.example.com")
+
+	c := clienttest.NewClient(t, option.WithProtectedDomains("old.example.com"))
+	domains := NewDomains(c)
+
+	result, err := domains.Replace(t.Context(), []string{"old.example.com"}, []string{"new.example.com"})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrProtectedDomain)
+	assert.Zero(t, httpmock.GetTotalCallCount())
+}
+
+func TestDomains_Replace_echo(t *testing.T) {
+	// the API echoes every requested domain, including those already registered
+	m := setupRegistryMock(t, "keep.example.com", "old.example.com")
+
+	c := clienttest.NewClient(t, option.WithChunkSize(1))
+	domains := NewDomains(c)
+
+	result, err := domains.Replace(t.Context(),
+		[]string{"keep.example.com", "old.example.com"},
+		[]string{"keep.example.com", "new.example.com", "fail-add.example.com"},
+	)
+
+	assert.Error(t, err)
+	assert.True(t, result.RolledBack)
+	assert.Equal(t, []string{"new.example.com"}, result.Added)
+	assert.Equal(t, [][]string{{"new.example.com"}}, m.deleted)
+	assert.Equal(t, []string{"keep.example.com", "old.example.com"}, result.Registered)
+}
@@ -0,0 +1,241 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+)
+
+// Set is a snapshot of registered domains with constant-time lookups.
+type Set struct {
+	domains []string
+	keys    map[string]string
+}
+
+// NewSet creates a new Set from the domains.
+func NewSet(domains []string) *Set {
+	s := &Set{
+		keys: make(map[string]string, len(domains)),
+	}
+	for _, domain := range domains {
+		k := key(domain)
+		if _, ok := s.keys[k]; ok {
+			continue
+		}
+		s.keys[k] = domain
+		s.domains = append(s.domains, domain)
+	}
+	return s
+}
+
+// Len returns the number of domains in the set.
+func (s *Set) Len() int {
+	return len(s.domains)
+}
+
+// Domains returns the domains in the set.
+func (s *Set) Domains() []string {
+	return s.domains
+}
+
+// Has returns true if the domain itself is in the set.
+func (s *Set) Has(domain string) bool {
+	_, ok := s.keys[key(domain)]
+	return ok
+}
+
+// Match returns the domain in the set that covers the host:
+// the host itself, a registered parent domain, or a wildcard of a parent domain.
+func (s *Set) Match(host string) (string, bool) {
+	k := key(host)
+	if domain, ok := s.keys[k]; ok {
+		return domain, true
+	}
+
+	k = strings.TrimPrefix(k, WildcardPrefix)
+	for {
+		_, parent, ok := strings.Cut(k, ".")
+		if !ok {
+			return "", false
+		}
+		if domain, ok := s.keys[WildcardPrefix+parent]; ok {
+			return domain, true
+		}
+		if domain, ok := s.keys[parent]; ok {
+			return domain, true
+		}
+		k = parent
+	}
+}
+
+// Contains returns true if the host is covered by a domain in the set.
+func (s *Set) Contains(host string) bool {
+	_, ok := s.Match(host)
+	return ok
+}
+
+// LoadSet walks the registered domains and returns them as a Set.
+func (d *Domains) LoadSet(
+	ctx context.Context,
+	options ...option.Option,
+) (*Set, error) {
+	var domains []string
+	for item, err := range d.List(nil, options...).Iter(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		domains = append(domains, item.Value)
+	}
+	return NewSet(domains), nil
+}
+
+// Contains returns true if the host is covered by a registered domain.
+// The registered domains are cached per API token and base URL for option.WithDomainCacheTTL
+// and refreshed when domains are added or deleted through the client.
+func (d *Domains) Contains(
+	ctx context.Context,
+	host string,
+	options ...option.Option,
+) (bool, error) {
+	set, err := d.cache.Get(ctx, options...)
+	if err != nil {
+		return false, err
+	}
+	return set.Contains(host), nil
+}
+
+// Cache is a refreshable Set of the registered domains.
+// The Sets are kept per API token and base URL, so that calls for different projects do not share them.
+// The lock is not held while the domains are fetched, and concurrent calls for the same key share one fetch.
+type Cache struct {
+	domains *Domains
+
+	mu      sync.Mutex
+	entries map[cacheKey]*cacheEntry
+	calls   map[cacheKey]*cacheCall
+}
+
+type cacheKey struct {
+	token   string
+	baseURL string
+}
+
+type cacheEntry struct {
+	set     *Set
+	expires time.Time
+}
+
+// cacheCall is a fetch of the registered domains in flight.
+type cacheCall struct {
+	done chan struct{}
+	set  *Set
+	err  error
+}
+
+// NewCache creates a new Cache that keeps the registered domains for option.WithDomainCacheTTL.
+func NewCache(d *Domains) *Cache {
+	return &Cache{
+		domains: d,
+		entries: map[cacheKey]*cacheEntry{},
+		calls:   map[cacheKey]*cacheCall{},
+	}
+}
+
+// Get returns the cached Set, refreshing it when it has expired.
+func (c *Cache) Get(
+	ctx context.Context,
+	options ...option.Option,
+) (*Set, error) {
+	key := c.key(options...)
+
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok && time.Now().Before(entry.expires) {
+		c.mu.Unlock()
+		return entry.set, nil
+	}
+	call, ok := c.calls[key]
+	if !ok {
+		call = c.fetch(ctx, key, options...)
+	}
+	c.mu.Unlock()
+
+	return call.wait(ctx)
+}
+
+// Refresh fetches the registered domains regardless of the expiration.
+func (c *Cache) Refresh(
+	ctx context.Context,
+	options ...option.Option,
+) (*Set, error) {
+	c.mu.Lock()
+	call := c.fetch(ctx, c.key(options...), options...)
+	c.mu.Unlock()
+
+	return call.wait(ctx)
+}
+
+// Invalidate discards all cached Sets.
+// The fetches in flight are not stored because they may predate the change.
+func (c *Cache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+	clear(c.calls)
+}
+
+// fetch starts fetching the domains of the key. It must be called with the lock held.
+// The fetch is not canceled with ctx since other calls may be waiting for it.
+func (c *Cache) fetch(
+	ctx context.Context,
+	key cacheKey,
+	options ...option.Option,
+) *cacheCall {
+	call := &cacheCall{done: make(chan struct{})}
+	c.calls[key] = call
+
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		defer close(call.done)
+
+		set, err := c.domains.LoadSet(ctx, options...)
+		call.set, call.err = set, err
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if c.calls[key] != call {
+			return
+		}
+		delete(c.calls, key)
+		if err == nil {
+			c.entries[key] = &cacheEntry{
+				set:     set,
+				expires: time.Now().Add(c.domains.client.Options(options...).DomainCacheTTL),
+			}
+		}
+	}()
+	return call
+}
+
+// wait returns the result of the fetch, or the error of ctx if it is done first.
+func (call *cacheCall) wait(ctx context.Context) (*Set, error) {
+	select {
+	case <-call.done:
+		return call.set, call.err
+	case <-ctx.Done():
+		return nil, context.Cause(ctx)
+	}
+}
+
+func (c *Cache) key(options ...option.Option) cacheKey {
+	o := c.domains.client.Options(options...)
+	key := cacheKey{token: o.APIToken}
+	if o.BaseURL != nil {
+		key.baseURL = o.BaseURL.String()
+	}
+	return key
+}
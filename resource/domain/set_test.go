@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/pager"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet_Match(t *testing.T) {
+	set := NewSet([]string{"example.com", "*.example.net", "www.example.org"})
+
+	tests := []struct {
+		name      string
+		host      string
+		wantMatch string
+		wantOK    bool
+	}{
+		{"exact", "example.com", "example.com", true},
+		{"exact not normalized", "https://Example.COM/", "example.com", true},
+		{"parent", "www.example.com", "example.com", true},
+		{"deep parent", "a.b.example.com", "example.com", true},
+		{"wildcard", "www.example.net", "*.example.net", true},
+		{"wildcard apex", "example.net", "", false},
+		{"wildcard itself", "*.example.net", "*.example.net", true},
+		{"sibling", "m.example.org", "", false},
+		{"child", "a.www.example.org", "www.example.org", true},
+		{"unknown", "example.jp", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := set.Match(tt.host)
+
+			assert.Equal(t, tt.wantMatch, got)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantOK, set.Contains(tt.host))
+		})
+	}
+}
+
+func TestSet_Has(t *testing.T) {
+	set := NewSet([]string{"example.com", "Example.com", "*.example.net"})
+
+	assert.Equal(t, 2, set.Len())
+	assert.Equal(t, []string{"example.com", "*.example.net"}, set.Domains())
+	assert.True(t, set.Has("EXAMPLE.com"))
+	assert.True(t, set.Has("*.example.net"))
+	assert.False(t, set.Has("www.example.com"))
+}
+
+func TestDomains_Contains(t *testing.T) {
+	setupRegistryMock(t, "example.com", "*.example.net")
+
+	c := clienttest.NewClient(t)
+	domains := NewDomains(c)
+
+	ok, err := domains.Contains(t.Context(), "www.example.com")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = domains.Contains(t.Context(), "example.org")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["GET https://api.morisawafonts.com/webfont/v1/domains"])
+
+	// invalidated by add
+	_, err = domains.Add(t.Context(), []string{"example.org"})
+	assert.NoError(t, err)
+
+	_, err = domains.Contains(t.Context(), "example.org")
+	assert.NoError(t, err)
+
+	// one list by Add and one by Contains
+	assert.Equal(t, 3, httpmock.GetCallCountInfo()["GET https://api.morisawafonts.com/webfont/v1/domains"])
+}
+
+func TestDomains_Contains_ttl(t *testing.T) {
+	setupRegistryMock(t, "example.com")
+
+	c := clienttest.NewClient(t, option.WithDomainCacheTTL(0))
+	domains := NewDomains(c)
+
+	for range 3 {
+		ok, err := domains.Contains(t.Context(), "example.com")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	}
+
+	assert.Equal(t, 3, httpmock.GetCallCountInfo()["GET https://api.morisawafonts.com/webfont/v1/domains"])
+}
+
+func TestDomains_Contains_perProject(t *testing.T) {
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/domains",
+		func(req *http.Request) (*http.Response, error) {
+			registered := map[string][]string{
+				"Bearer test-token":  {"example.com"},
+				"Bearer other-token": {"example.org"},
+			}[req.Header.Get("Authorization")]
+			return httpmock.NewJsonResponse(http.StatusOK, pager.Page[string, *ListMetadata]{
+				Result: registered,
+				Meta:   &ListMetadata{},
+			})
+		},
+	)
+
+	c := clienttest.NewClient(t)
+	domains := NewDomains(c)
+
+	ok, err := domains.Contains(t.Context(), "example.com")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = domains.Contains(t.Context(), "example.com", option.WithAPIToken("other-token"))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = domains.Contains(t.Context(), "example.org", option.WithAPIToken("other-token"))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+}
+
+func TestDomains_Contains_ttlOption(t *testing.T) {
+	setupRegistryMock(t, "example.com")
+
+	c := clienttest.NewClient(t)
+	domains := NewDomains(c)
+
+	for range 3 {
+		ok, err := domains.Contains(t.Context(), "example.com", option.WithDomainCacheTTL(0))
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	}
+
+	assert.Equal(t, 3, httpmock.GetCallCountInfo()["GET https://api.morisawafonts.com/webfont/v1/domains"])
+}
+
+func TestDomains_Contains_concurrent(t *testing.T) {
+	release := make(chan struct{})
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/domains",
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("Authorization") == "Bearer test-token" {
+				<-release
+			}
+			return httpmock.NewJsonResponse(http.StatusOK, pager.Page[string, *ListMetadata]{
+				Result: []string{"example.com"},
+				Meta:   &ListMetadata{},
+			})
+		},
+	)
+
+	c := clienttest.NewClient(t)
+	domains := NewDomains(c)
+
+	// A slow fetch does not block the calls for other projects.
+	results := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := domains.Contains(t.Context(), "example.com")
+			results <- err
+		}()
+	}
+	ok, err := domains.Contains(t.Context(), "example.com", option.WithAPIToken("other-token"))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// Waiting calls return when their own context is done.
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	_, err = domains.Contains(ctx, "example.com")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The calls for the same project share one fetch.
+	close(release)
+	assert.NoError(t, <-results)
+	assert.NoError(t, <-results)
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+}
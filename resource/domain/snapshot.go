@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+)
+
+// Snapshot is the registered domains of a project captured at a point in time.
+// It can be serialized to JSON and compared with other snapshots.
+type Snapshot struct {
+	ProjectID  string    `json:"project_id"`
+	CapturedAt time.Time `json:"captured_at"`
+	Domains    []string  `json:"domains"`
+}
+
+// Diff describes the changes between two snapshots.
+type Diff struct {
+	ProjectID string    `json:"project_id"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	Added     []string  `json:"added"`
+	Removed   []string  `json:"removed"`
+}
+
+// Snapshot captures the registered domains.
+func (d *Domains) Snapshot(
+	ctx context.Context,
+	options ...option.Option,
+) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		CapturedAt: time.Now(),
+		Domains:    []string{},
+	}
+	// pages are walked directly so that the project ID is taken even without domains
+	p := d.List(nil, options...)
+	for p.HasNextPage() {
+		page, err := p.GetNextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if page.Meta != nil && snapshot.ProjectID == "" {
+			snapshot.ProjectID = page.Meta.ProjectID
+		}
+		snapshot.Domains = append(snapshot.Domains, page.Result...)
+	}
+	slices.Sort(snapshot.Domains)
+	return snapshot, nil
+}
+
+// DiffSnapshot compares the snapshot with the registered domains.
+func (d *Domains) DiffSnapshot(
+	ctx context.Context,
+	snapshot *Snapshot,
+	options ...option.Option,
+) (*Diff, error) {
+	live, err := d.Snapshot(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.Diff(live), nil
+}
+
+// LoadSnapshot reads a snapshot serialized as JSON.
+func LoadSnapshot(r io.Reader) (*Snapshot, error) {
+	var snapshot Snapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// Save writes the snapshot as JSON.
+func (s *Snapshot) Save(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
+
+// Diff returns the changes from the snapshot to the other snapshot.
+func (s *Snapshot) Diff(other *Snapshot) *Diff {
+	before := NewSet(s.Domains)
+	after := NewSet(other.Domains)
+
+	diff := &Diff{
+		ProjectID: other.ProjectID,
+		From:      s.CapturedAt,
+		To:        other.CapturedAt,
+		Added:     []string{},
+		Removed:   []string{},
+	}
+	for _, domain := range after.Domains() {
+		if !before.Has(domain) {
+			diff.Added = append(diff.Added, domain)
+		}
+	}
+	for _, domain := range before.Domains() {
+		if !after.Has(domain) {
+			diff.Removed = append(diff.Removed, domain)
+		}
+	}
+	return diff
+}
+
+// Empty returns true if nothing changed.
+func (d *Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
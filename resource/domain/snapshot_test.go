@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomains_Snapshot(t *testing.T) {
+	setupRegistryMock(t, "b.example.com", "a.example.com")
+
+	c := clienttest.NewClient(t)
+	domains := NewDomains(c)
+
+	snapshot, err := domains.Snapshot(t.Context())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "project", snapshot.ProjectID)
+	assert.Equal(t, []string{"a.example.com", "b.example.com"}, snapshot.Domains)
+	assert.WithinDuration(t, time.Now(), snapshot.CapturedAt, time.Minute)
+}
+
+func TestDomains_Snapshot_empty(t *testing.T) {
+	setupRegistryMock(t)
+
+	c := clienttest.NewClient(t)
+	domains := NewDomains(c)
+
+	snapshot, err := domains.Snapshot(t.Context())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "project", snapshot.ProjectID)
+	assert.Empty(t, snapshot.Domains)
+	assert.NotNil(t, snapshot.Domains)
+}
+
+func TestSnapshot_Save(t *testing.T) {
+	snapshot := &Snapshot{
+		ProjectID:  "project",
+		CapturedAt: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+		Domains:    []string{"a.example.com"},
+	}
+
+	var buf bytes.Buffer
+	err := snapshot.Save(&buf)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"project_id": "project", "captured_at": "2025-09-01T00:00:00Z", "domains": ["a.example.com"]}`, buf.String())
+
+	loaded, err := LoadSnapshot(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, snapshot, loaded)
+}
+
+func TestSnapshot_Diff(t *testing.T) {
+	lastWeek := &Snapshot{
+		ProjectID:  "project",
+		CapturedAt: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+		Domains:    []string{"a.example.com", "b.example.com"},
+	}
+	today := &Snapshot{
+		ProjectID:  "project",
+		CapturedAt: time.Date(2025, 9, 8, 0, 0, 0, 0, time.UTC),
+		Domains:    []string{"b.example.com", "c.example.com"},
+	}
+
+	diff := lastWeek.Diff(today)
+
+	assert.Equal(t, &Diff{
+		ProjectID: "project",
+		From:      lastWeek.CapturedAt,
+		To:        today.CapturedAt,
+		Added:     []string{"c.example.com"},
+		Removed:   []string{"a.example.com"},
+	}, diff)
+	assert.False(t, diff.Empty())
+	assert.True(t, today.Diff(today).Empty())
+}
+
+func TestDomains_DiffSnapshot(t *testing.T) {
+	setupRegistryMock(t, "b.example.com", "c.example.com")
+
+	c := clienttest.NewClient(t)
+	domains := NewDomains(c)
+
+	diff, err := domains.DiffSnapshot(t.Context(), &Snapshot{
+		ProjectID: "project",
+		Domains:   []string{"a.example.com", "b.example.com"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c.example.com"}, diff.Added)
+	assert.Equal(t, []string{"a.example.com"}, diff.Removed)
+}
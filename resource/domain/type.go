@@ -12,4 +12,36 @@ type ListMetadata struct {
 
 type AddResult struct {
 	Domains []string `json:"domains"`
+
+	// Outcomes describes the outcome of each requested domain in the order of the request.
+	// It is computed by the client from the request and the response.
+	Outcomes []*AddOutcome `json:"-"`
+}
+
+// AddStatus represents the outcome of a requested domain.
+type AddStatus string
+
+const (
+	// AddStatusAdded means the domain was newly registered as requested.
+	AddStatusAdded AddStatus = "added"
+	// AddStatusNormalized means the domain was newly registered in a normalized form.
+	AddStatusNormalized AddStatus = "normalized"
+	// AddStatusExisting means the domain was already registered before the call.
+	AddStatusExisting AddStatus = "existing"
+	// AddStatusDuplicate means the domain is the same as a domain requested earlier.
+	AddStatusDuplicate AddStatus = "duplicate"
+	// AddStatusMissing means the domain was not returned by the API,
+	// which echoes every registered domain, so it is not registered.
+	AddStatusMissing AddStatus = "missing"
+	// AddStatusFailed means the request for the chunk containing the domain failed.
+	AddStatusFailed AddStatus = "failed"
+)
+
+// AddOutcome describes the outcome of a requested domain.
+type AddOutcome struct {
+	// Requested is the domain as passed to Add.
+	Requested string
+	// Registered is the domain as returned by the API, or empty if not returned.
+	Registered string
+	Status     AddStatus
 }
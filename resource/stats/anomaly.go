@@ -0,0 +1,250 @@
+package stats
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/parallel"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+)
+
+// AnomalyMethod represents how the deviation from the baseline is scored.
+type AnomalyMethod string
+
+const (
+	// AnomalyZScore scores the deviation from the mean in standard deviations.
+	AnomalyZScore AnomalyMethod = "zscore"
+	// AnomalyMAD scores the deviation from the median in median absolute deviations,
+	// scaled to be comparable to standard deviations. It is robust to past anomalies in the baseline.
+	AnomalyMAD AnomalyMethod = "mad"
+)
+
+// AnomalyDirection represents whether an anomaly is above or below the baseline.
+type AnomalyDirection string
+
+const (
+	AnomalySpike AnomalyDirection = "spike"
+	AnomalyDrop  AnomalyDirection = "drop"
+)
+
+const (
+	// DefaultAnomalyWindow is the number of preceding buckets in the baseline by default.
+	DefaultAnomalyWindow = 12
+	// DefaultZScoreThreshold is the score flagged by AnomalyZScore by default.
+	DefaultZScoreThreshold = 3.0
+	// DefaultMADThreshold is the score flagged by AnomalyMAD by default.
+	DefaultMADThreshold = 3.5
+)
+
+// madScale makes the median absolute deviation consistent with the standard deviation of a normal distribution.
+const madScale = 1.4826
+
+// Anomaly is a bucket whose page views deviate from the baseline.
+type Anomaly struct {
+	// Domain is empty for the page views of the project.
+	Domain    string           `json:"domain,omitempty"`
+	Month     Month            `json:"month"`
+	Value     int              `json:"value"`
+	Baseline  float64          `json:"baseline"`
+	Score     float64          `json:"score"`
+	Direction AnomalyDirection `json:"direction"`
+}
+
+// Detector flags buckets deviating from a rolling baseline of the preceding buckets.
+// The zero value uses AnomalyZScore over DefaultAnomalyWindow buckets.
+type Detector struct {
+	Method AnomalyMethod
+	// Window is the number of preceding buckets in the baseline.
+	Window int
+	// Threshold is the absolute score flagged as an anomaly.
+	// Default: DefaultZScoreThreshold or DefaultMADThreshold depending on the method
+	Threshold float64
+}
+
+// Detect flags the buckets of the series deviating from the baseline.
+// The buckets are skipped as DetectValues does.
+func (d Detector) Detect(series *Series) []*Anomaly {
+	months := make([]Month, len(series.Buckets))
+	values := make([]int, len(series.Buckets))
+	for i, b := range series.Buckets {
+		months[i] = b.Period.From
+		values[i] = b.Total
+	}
+	return d.DetectValues("", months, values)
+}
+
+// DetectValues flags the values deviating from the baseline.
+// The months correspond to the values, and the domain is set to the anomalies.
+// Leading zeros are months before there was any data and are not part of any baseline,
+// so the first value flagged is Window values after the first nonzero value.
+//
+// The spread of the baseline is at least the square root of its center, which is the noise expected of counts,
+// so that a flat baseline does not flag small changes.
+func (d Detector) DetectValues(domain string, months []Month, values []int) []*Anomaly {
+	window := d.Window
+	if window <= 0 {
+		window = DefaultAnomalyWindow
+	}
+	threshold := d.Threshold
+	if threshold <= 0 {
+		threshold = DefaultZScoreThreshold
+		if d.Method == AnomalyMAD {
+			threshold = DefaultMADThreshold
+		}
+	}
+
+	start := slices.IndexFunc(values, func(v int) bool { return v != 0 })
+	if start < 0 {
+		return nil
+	}
+
+	var anomalies []*Anomaly
+	for i := start + window; i < len(values); i++ {
+		baseline := values[i-window : i]
+
+		var center, spread float64
+		if d.Method == AnomalyMAD {
+			center, spread = medianAbsoluteDeviation(baseline)
+		} else {
+			center, spread = meanStandardDeviation(baseline)
+		}
+		spread = max(spread, math.Sqrt(max(center, 1)))
+
+		score := (float64(values[i]) - center) / spread
+		if math.Abs(score) < threshold {
+			continue
+		}
+
+		direction := AnomalySpike
+		if score < 0 {
+			direction = AnomalyDrop
+		}
+		anomalies = append(anomalies, &Anomaly{
+			Domain:    domain,
+			Month:     months[i],
+			Value:     values[i],
+			Baseline:  center,
+			Score:     score,
+			Direction: direction,
+		})
+	}
+	return anomalies
+}
+
+// Analyze retrieves the monthly page views of the project and of each domain in the period,
+// and flags the months deviating from the baseline.
+// The Window months before the period are also retrieved so that every month of the period has a baseline,
+// unless they are older than option.WithMaxAge, in which case the first months have no baseline and are not flagged.
+// The current month is excluded because its page views are still incomplete.
+// The anomalies are ordered by month, with the project first and then the domains by name.
+func (d Detector) Analyze(
+	ctx context.Context,
+	pv *PV,
+	period Period,
+	options ...option.Option,
+) ([]*Anomaly, error) {
+	if err := period.Validate(); err != nil {
+		return nil, err
+	}
+
+	window := d.Window
+	if window <= 0 {
+		window = DefaultAnomalyWindow
+	}
+	last := period.To
+	if closed := CurrentMonth().AddMonths(-1); last.After(closed) {
+		last = closed
+	}
+	if last.Before(period.From) {
+		return nil, nil
+	}
+	from := period.From.AddMonths(-window)
+	if maxAge := pv.client.Options(options...).MaxAge; maxAge > 0 {
+		oldest := CurrentMonth().AddMonths(1 - maxAge)
+		if last.Before(oldest) {
+			return nil, &PeriodError{Period: period, Reason: fmt.Sprintf("older than %d months", maxAge)}
+		}
+		if from.Before(oldest) {
+			from = oldest
+		}
+	}
+	extended := NewPeriod(from, last)
+
+	series, err := pv.Series(ctx, extended, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	periods := extended.Split()
+	results := make([]*DomainsListAllResponse, len(periods))
+	err = parallel.ForEach(ctx, len(periods), pv.client.Options(options...).Concurrency, func(ctx context.Context, i int) error {
+		result, err := pv.Domains.ListAll(ctx, &DomainsListInput{Period: &periods[i]}, options...)
+		results[i] = result
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	months := make([]Month, len(periods))
+	values := map[string][]int{}
+	for i, result := range results {
+		months[i] = periods[i].From
+		for _, r := range result.Domains {
+			if _, ok := values[r.Domain]; !ok {
+				values[r.Domain] = make([]int, len(periods))
+			}
+			values[r.Domain][i] += r.Value
+		}
+	}
+
+	anomalies := d.Detect(series)
+	for domain, v := range values {
+		anomalies = append(anomalies, d.DetectValues(domain, months, v)...)
+	}
+	slices.SortFunc(anomalies, func(a, b *Anomaly) int {
+		return cmp.Or(a.Month.Compare(b.Month), cmp.Compare(a.Domain, b.Domain))
+	})
+	return anomalies, nil
+}
+
+func meanStandardDeviation(values []int) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += float64(v)
+	}
+	mean := sum / float64(len(values))
+
+	var squares float64
+	for _, v := range values {
+		squares += (float64(v) - mean) * (float64(v) - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(values)))
+}
+
+func medianAbsoluteDeviation(values []int) (float64, float64) {
+	sorted := make([]float64, len(values))
+	for i, v := range values {
+		sorted[i] = float64(v)
+	}
+	median := medianOf(sorted)
+
+	deviations := make([]float64, len(values))
+	for i, v := range sorted {
+		deviations[i] = math.Abs(v - median)
+	}
+	return median, medianOf(deviations) * madScale
+}
+
+// medianOf returns the median of the values, sorting them in place.
+func medianOf(values []float64) float64 {
+	slices.Sort(values)
+	n := len(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
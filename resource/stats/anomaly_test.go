@@ -0,0 +1,192 @@
+package stats
+
+import (
+	"bytes"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/export"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/pager"
+	"github.com/stretchr/testify/assert"
+)
+
+func months(from Month, n int) []Month {
+	ms := make([]Month, n)
+	for i := range ms {
+		ms[i] = from.AddMonths(i)
+	}
+	return ms
+}
+
+func TestDetector_DetectValues(t *testing.T) {
+	ds := months(NewMonth(2025, time.January), 8)
+	values := []int{100, 110, 90, 105, 95, 400, 100, 10}
+
+	anomalies := Detector{Window: 5}.DetectValues("example.com", ds, values)
+
+	assert.Len(t, anomalies, 1)
+	assert.Equal(t, "example.com", anomalies[0].Domain)
+	assert.Equal(t, NewMonth(2025, time.June), anomalies[0].Month)
+	assert.Equal(t, 400, anomalies[0].Value)
+	assert.InDelta(t, 100.0, anomalies[0].Baseline, 1e-9)
+	assert.Equal(t, AnomalySpike, anomalies[0].Direction)
+
+	// The median absolute deviation is not inflated by the spike in the baseline.
+	anomalies = Detector{Method: AnomalyMAD, Window: 5}.DetectValues("example.com", ds, values)
+
+	assert.Len(t, anomalies, 2)
+	assert.Equal(t, NewMonth(2025, time.August), anomalies[1].Month)
+	assert.Equal(t, AnomalyDrop, anomalies[1].Direction)
+	assert.InDelta(t, 100.0, anomalies[1].Baseline, 1e-9)
+}
+
+func TestDetector_DetectValues_flat(t *testing.T) {
+	ds := months(NewMonth(2025, time.January), 5)
+
+	// A flat baseline tolerates the noise expected of counts.
+	assert.Empty(t, Detector{Window: 4}.DetectValues("", ds, []int{100, 100, 100, 100, 120}))
+	assert.Len(t, Detector{Window: 4}.DetectValues("", ds, []int{100, 100, 100, 100, 140}), 1)
+	assert.Empty(t, Detector{Window: 4, Threshold: 5}.DetectValues("", ds, []int{100, 100, 100, 100, 140}))
+	assert.Empty(t, Detector{Window: 4}.DetectValues("", ds, []int{0, 0, 0, 0, 2}))
+}
+
+func TestDetector_DetectValues_leadingZeros(t *testing.T) {
+	ds := months(NewMonth(2025, time.January), 9)
+	values := []int{0, 0, 0, 0, 100, 110, 90, 100, 1000}
+
+	// The months before the first page views do not form a baseline of zeros.
+	anomalies := Detector{Window: 3}.DetectValues("", ds, values)
+
+	assert.Len(t, anomalies, 1)
+	assert.Equal(t, NewMonth(2025, time.September), anomalies[0].Month)
+	assert.InDelta(t, 100.0, anomalies[0].Baseline, 1e-9)
+
+	assert.Empty(t, Detector{Window: 3}.DetectValues("", ds, make([]int, 9)))
+}
+
+func TestDetector_Analyze(t *testing.T) {
+	setNow(t, 2025, time.September, 15)
+	project := map[string]int{"2025-07": 1000, "2025-09": 100000}
+	domains := map[string][]*DomainsListResult{
+		"2025-02": {{Domain: "hotlink.example.net", Value: 100}},
+		"2025-03": {{Domain: "hotlink.example.net", Value: 100}},
+		"2025-04": {{Domain: "hotlink.example.net", Value: 100}},
+		"2025-05": {{Domain: "hotlink.example.net", Value: 100}},
+		"2025-06": {{Domain: "hotlink.example.net", Value: 500}},
+		"2025-07": {{Domain: "hotlink.example.net", Value: 5000}, {Domain: "new.example.org", Value: 3000}},
+		"2025-08": {{Domain: "new.example.org", Value: 3000}},
+	}
+
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv",
+		func(req *http.Request) (*http.Response, error) {
+			from := req.URL.Query().Get("from")
+			return httpmock.NewJsonResponse(http.StatusOK, &PVGetResponse{
+				PV: &PVGetResult{Total: 100 + project[from]},
+			})
+		},
+	)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv/domains",
+		func(req *http.Request) (*http.Response, error) {
+			from := req.URL.Query().Get("from")
+			return httpmock.NewJsonResponse(http.StatusOK, pager.Page[*DomainsListResult, *DomainsListMetadata]{
+				Result: slices.Concat([]*DomainsListResult{{Domain: "example.com", Value: 100}}, domains[from]),
+				Meta:   &DomainsListMetadata{},
+			})
+		},
+	)
+
+	c := clienttest.NewClient(t)
+	pv := NewPV(c)
+
+	period := NewPeriod(NewMonth(2025, time.May), NewMonth(2025, time.September))
+	anomalies, err := Detector{Window: 3}.Analyze(t.Context(), pv, period)
+
+	// new.example.org is not flagged without a baseline of months in which it had page views.
+	assert.NoError(t, err)
+	assert.Len(t, anomalies, 3)
+	assert.Equal(t, "hotlink.example.net", anomalies[0].Domain)
+	assert.Equal(t, NewMonth(2025, time.June), anomalies[0].Month)
+	assert.Equal(t, &Anomaly{Month: NewMonth(2025, time.July), Value: 1100, Baseline: 100, Score: 100, Direction: AnomalySpike}, anomalies[1])
+	assert.Equal(t, "hotlink.example.net", anomalies[2].Domain)
+	assert.Equal(t, NewMonth(2025, time.July), anomalies[2].Month)
+	// 7 months from February to August including the baseline, each requested once for the project
+	// and once for the domains. The incomplete current month is not analyzed.
+	assert.Equal(t, 14, httpmock.GetTotalCallCount())
+
+	// A period of the current month only has nothing to analyze.
+	current, err := Detector{Window: 3}.Analyze(t.Context(), pv, ThisMonth())
+
+	assert.NoError(t, err)
+	assert.Empty(t, current)
+	assert.Equal(t, 14, httpmock.GetTotalCallCount())
+
+	var buf bytes.Buffer
+	err = export.WriteValues(&buf, export.CSV, func(yield func(*Anomaly, error) bool) {
+		for _, a := range anomalies[1:2] {
+			if !yield(a, nil) {
+				return
+			}
+		}
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "domain,month,value,baseline,score,direction\n,2025-07,1100,100,100,spike\n", buf.String())
+}
+
+func TestDetector_Analyze_maxAge(t *testing.T) {
+	setNow(t, 2025, time.September, 15)
+
+	var (
+		mu        sync.Mutex
+		requested []string
+	)
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv",
+		func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			requested = append(requested, req.URL.Query().Get("from"))
+			mu.Unlock()
+
+			return httpmock.NewJsonResponse(http.StatusOK, &PVGetResponse{
+				PV: &PVGetResult{Total: 100},
+			})
+		},
+	)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv/domains",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, pager.Page[*DomainsListResult, *DomainsListMetadata]{
+			Meta: &DomainsListMetadata{},
+		}),
+	)
+
+	c := clienttest.NewClient(t, option.WithMaxAge(12))
+	pv := NewPV(c)
+
+	// The baseline of 12 months before August is clipped to the 12 months available.
+	anomalies, err := Detector{}.Analyze(t.Context(), pv, LastMonth())
+
+	assert.NoError(t, err)
+	assert.Empty(t, anomalies)
+	slices.Sort(requested)
+	assert.Equal(t, "2024-10", requested[0])
+	assert.Equal(t, "2025-08", requested[len(requested)-1])
+	assert.Len(t, requested, 11)
+
+	_, err = Detector{}.Analyze(t.Context(), pv, NewMonth(2024, time.September).Period())
+
+	assert.ErrorIs(t, err, ErrInvalidPeriod)
+}
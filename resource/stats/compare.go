@@ -0,0 +1,190 @@
+package stats
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/parallel"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+)
+
+// DomainStatus represents how the page views of a domain changed between two periods.
+type DomainStatus string
+
+const (
+	// DomainNew has page views only in the current period.
+	DomainNew DomainStatus = "new"
+	// DomainDisappeared has page views only in the previous period.
+	DomainDisappeared DomainStatus = "disappeared"
+	// DomainGrowing has more page views in the current period than in the previous period.
+	DomainGrowing DomainStatus = "growing"
+	// DomainShrinking has fewer page views in the current period than in the previous period.
+	DomainShrinking DomainStatus = "shrinking"
+	// DomainUnchanged has the same page views in both periods.
+	DomainUnchanged DomainStatus = "unchanged"
+)
+
+// Comparison is page views of a period compared with a previous period.
+type Comparison struct {
+	ProjectID string
+	Current   Period
+	Previous  Period
+	Change    Change
+
+	// Domains is ordered by the absolute change, descending.
+	Domains []*DomainComparison
+}
+
+// Change is the difference between the page views of two periods.
+type Change struct {
+	Current  int
+	Previous int
+	// Delta is Current minus Previous.
+	Delta int
+	// Percent is Delta relative to Previous, or nil if Previous is zero.
+	Percent *float64
+}
+
+// DomainComparison is page views of a domain compared with a previous period.
+type DomainComparison struct {
+	Domain string
+	Status DomainStatus
+	Change Change
+}
+
+// Previous returns the period of the same number of months immediately before the period.
+func (p Period) Previous() Period {
+	months := (p.To.Year-p.From.Year)*12 + int(p.To.Month-p.From.Month) + 1
+	return NewPeriod(p.From.AddMonths(-months), p.To.AddMonths(-months))
+}
+
+// YearBefore returns the same period one year earlier.
+func (p Period) YearBefore() Period {
+	return NewPeriod(p.From.AddMonths(-12), p.To.AddMonths(-12))
+}
+
+// DomainsWith returns the domains of the status.
+func (c *Comparison) DomainsWith(status DomainStatus) []*DomainComparison {
+	var domains []*DomainComparison
+	for _, d := range c.Domains {
+		if d.Status == status {
+			domains = append(domains, d)
+		}
+	}
+	return domains
+}
+
+// Compare retrieves page views of the current and previous periods and compares them.
+// The domains are collected with Domains.ListAll, so option.WithMaxRange applies to both periods.
+func (p *PV) Compare(
+	ctx context.Context,
+	current Period,
+	previous Period,
+	options ...option.Option,
+) (*Comparison, error) {
+	periods := []Period{current, previous}
+	for _, period := range periods {
+		if err := period.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	totals := make([]*PVGetResponse, len(periods))
+	domains := make([]*DomainsListAllResponse, len(periods))
+	err := parallel.ForEach(ctx, len(periods)*2, p.client.Options(options...).Concurrency, func(ctx context.Context, i int) error {
+		period := periods[i/2]
+		if i%2 == 0 {
+			result, err := p.Get(ctx, &PVGetInput{Period: &period}, options...)
+			totals[i/2] = result
+			return err
+		}
+		result, err := p.Domains.ListAll(ctx, &DomainsListInput{Period: &period}, options...)
+		domains[i/2] = result
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &Comparison{
+		Current:  current,
+		Previous: previous,
+		Change:   newChange(totals[0].PV.Total, totals[1].PV.Total),
+		Domains:  compareDomains(domains[0].Domains, domains[1].Domains),
+	}
+	if totals[0].Meta != nil {
+		comparison.ProjectID = totals[0].Meta.ProjectID
+	}
+	return comparison, nil
+}
+
+// compareDomains compares the values of each domain in the current and previous results.
+func compareDomains(current, previous []*DomainsListResult) []*DomainComparison {
+	values := map[string]*Change{}
+	var names []string
+	change := func(domain string) *Change {
+		if c, ok := values[domain]; ok {
+			return c
+		}
+		c := &Change{}
+		values[domain] = c
+		names = append(names, domain)
+		return c
+	}
+	for _, r := range current {
+		change(r.Domain).Current += r.Value
+	}
+	for _, r := range previous {
+		change(r.Domain).Previous += r.Value
+	}
+
+	comparisons := make([]*DomainComparison, 0, len(names))
+	for _, name := range names {
+		c := newChange(values[name].Current, values[name].Previous)
+		comparisons = append(comparisons, &DomainComparison{
+			Domain: name,
+			Status: status(c),
+			Change: c,
+		})
+	}
+
+	slices.SortStableFunc(comparisons, func(a, b *DomainComparison) int {
+		return cmp.Or(
+			cmp.Compare(abs(b.Change.Delta), abs(a.Change.Delta)),
+			cmp.Compare(a.Domain, b.Domain),
+		)
+	})
+	return comparisons
+}
+
+func newChange(current, previous int) Change {
+	c := Change{
+		Current:  current,
+		Previous: previous,
+		Delta:    current - previous,
+	}
+	if previous != 0 {
+		percent := float64(c.Delta) / float64(previous) * 100
+		c.Percent = &percent
+	}
+	return c
+}
+
+func status(c Change) DomainStatus {
+	switch {
+	case c.Previous == 0 && c.Current > 0:
+		return DomainNew
+	case c.Current == 0 && c.Previous > 0:
+		return DomainDisappeared
+	case c.Delta > 0:
+		return DomainGrowing
+	case c.Delta < 0:
+		return DomainShrinking
+	}
+	return DomainUnchanged
+}
+
+func abs(n int) int {
+	return max(n, -n)
+}
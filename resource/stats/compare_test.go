@@ -0,0 +1,134 @@
+package stats
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/pager"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeriod_Previous(t *testing.T) {
+	assert.Equal(t,
+		NewPeriod(NewMonth(2025, 7), NewMonth(2025, 7)),
+		NewMonth(2025, 8).Period().Previous(),
+	)
+	assert.Equal(t,
+		NewPeriod(NewMonth(2024, 11), NewMonth(2025, 1)),
+		NewPeriod(NewMonth(2025, 2), NewMonth(2025, 4)).Previous(),
+	)
+	assert.Equal(t,
+		NewPeriod(NewMonth(2024, 6), NewMonth(2024, 12)),
+		NewPeriod(NewMonth(2025, 1), NewMonth(2025, 7)).Previous(),
+	)
+}
+
+func TestPeriod_YearBefore(t *testing.T) {
+	assert.Equal(t, NewMonth(2024, 8).Period(), NewMonth(2025, 8).Period().YearBefore())
+	assert.Equal(t,
+		NewPeriod(NewMonth(2023, 12), NewMonth(2024, 2)),
+		NewPeriod(NewMonth(2024, 12), NewMonth(2025, 2)).YearBefore(),
+	)
+}
+
+func TestPV_Compare(t *testing.T) {
+	setNow(t, 2025, 8, 20)
+
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv",
+		func(req *http.Request) (*http.Response, error) {
+			total := map[string]int{"2025-08": 1500, "2025-07": 1000}[req.URL.Query().Get("from")]
+			return httpmock.NewJsonResponse(http.StatusOK, &PVGetResponse{
+				PV:   &PVGetResult{Total: total},
+				Meta: &PVGetMetadata{ProjectID: "project"},
+			})
+		},
+	)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv/domains",
+		func(req *http.Request) (*http.Response, error) {
+			results := map[string][]*DomainsListResult{
+				"2025-08": {
+					{Domain: "growing.example.com", Value: 800},
+					{Domain: "new.example.com", Value: 400},
+					{Domain: "shrinking.example.com", Value: 200},
+					{Domain: "unchanged.example.com", Value: 100},
+				},
+				"2025-07": {
+					{Domain: "growing.example.com", Value: 400},
+					{Domain: "shrinking.example.com", Value: 300},
+					{Domain: "disappeared.example.com", Value: 200},
+					{Domain: "unchanged.example.com", Value: 100},
+				},
+			}[req.URL.Query().Get("from")]
+			return httpmock.NewJsonResponse(http.StatusOK, pager.Page[*DomainsListResult, *DomainsListMetadata]{
+				Result: results,
+				Meta:   &DomainsListMetadata{ProjectID: "project"},
+			})
+		},
+	)
+
+	c := clienttest.NewClient(t, option.WithConcurrency(4))
+	pv := NewPV(c)
+
+	current := NewMonth(2025, 8).Period()
+	comparison, err := pv.Compare(t.Context(), current, current.Previous())
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Comparison{
+		ProjectID: "project",
+		Current:   current,
+		Previous:  NewMonth(2025, 7).Period(),
+		Change:    Change{Current: 1500, Previous: 1000, Delta: 500, Percent: lo.ToPtr(50.0)},
+		Domains: []*DomainComparison{
+			{
+				Domain: "growing.example.com",
+				Status: DomainGrowing,
+				Change: Change{Current: 800, Previous: 400, Delta: 400, Percent: lo.ToPtr(100.0)},
+			},
+			{
+				Domain: "new.example.com",
+				Status: DomainNew,
+				Change: Change{Current: 400, Delta: 400},
+			},
+			{
+				Domain: "disappeared.example.com",
+				Status: DomainDisappeared,
+				Change: Change{Previous: 200, Delta: -200, Percent: lo.ToPtr(-100.0)},
+			},
+			{
+				Domain: "shrinking.example.com",
+				Status: DomainShrinking,
+				Change: Change{Current: 200, Previous: 300, Delta: -100, Percent: lo.ToPtr(float64(-100) / 300 * 100)},
+			},
+			{
+				Domain: "unchanged.example.com",
+				Status: DomainUnchanged,
+				Change: Change{Current: 100, Previous: 100, Percent: lo.ToPtr(0.0)},
+			},
+		},
+	}, comparison)
+	assert.Len(t, comparison.DomainsWith(DomainNew), 1)
+	assert.Empty(t, comparison.DomainsWith("unknown"))
+}
+
+func TestPV_Compare_invalidPeriod(t *testing.T) {
+	setNow(t, 2025, 8, 20)
+	httpmock.Activate(t)
+
+	c := clienttest.NewClient(t)
+	pv := NewPV(c)
+
+	comparison, err := pv.Compare(t.Context(), NewMonth(2025, 9).Period(), NewMonth(2025, 8).Period())
+
+	assert.Nil(t, comparison)
+	assert.ErrorIs(t, err, ErrInvalidPeriod)
+	assert.Zero(t, httpmock.GetTotalCallCount())
+}
@@ -19,9 +19,14 @@ func NewDomains(c *client.Client) *Domains {
 }
 
 // List returns a paginated list of page view statistics by domain.
+// The pager returns a *PeriodError without any request if the input period is invalid.
+// The period is not split by option.WithMaxRange; use ListAll for long periods.
 func (d *Domains) List(
 	input *DomainsListInput,
 	options ...option.Option,
 ) *pager.Pager[*DomainsListResult, *DomainsListMetadata] {
+	if err := input.Validate(); err != nil {
+		return pager.NewErrorPager[*DomainsListResult, *DomainsListMetadata](err)
+	}
 	return pager.NewPager[*DomainsListResult, *DomainsListMetadata](d.client, "/stats/pv/domains", input.Values(), options...)
 }
@@ -90,3 +90,24 @@ func TestDomains_List(t *testing.T) {
 	}
 	assert.Equal(t, 4, i)
 }
+
+func TestDomains_List_invalidPeriod(t *testing.T) {
+	httpmock.Activate(t)
+
+	c := clienttest.NewClient(t)
+	domains := NewDomains(c)
+
+	list := domains.List(&DomainsListInput{
+		Period: &Period{From: NewMonth(2025, 9), To: NewMonth(2025, 8)},
+	})
+
+	i := 0
+	for item, err := range list.Iter(t.Context()) {
+		i++
+		assert.Nil(t, item)
+		assert.ErrorIs(t, err, ErrInvalidPeriod)
+	}
+	assert.Equal(t, 1, i)
+	assert.False(t, list.HasNextPage())
+	assert.Zero(t, httpmock.GetTotalCallCount())
+}
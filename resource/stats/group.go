@@ -0,0 +1,152 @@
+package stats
+
+import (
+	"cmp"
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+	"golang.org/x/net/publicsuffix"
+)
+
+// Ungrouped is the name of the group of the domains that no grouper matched.
+const Ungrouped = "ungrouped"
+
+// Grouper returns the name of the group of a domain, or an empty string if the domain does not match.
+type Grouper interface {
+	Group(domain string) string
+}
+
+// GrouperFunc is an adapter to allow the use of an ordinary function as a Grouper.
+type GrouperFunc func(domain string) string
+
+// Group calls f(domain).
+func (f GrouperFunc) Group(domain string) string {
+	return f(domain)
+}
+
+// ByRegistrableDomain groups domains by their registrable domain (eTLD+1) in the public suffix list,
+// such as "example.co.jp" for "www.example.co.jp".
+// A domain without a registrable domain, such as a public suffix itself, is its own group.
+func ByRegistrableDomain() Grouper {
+	return GrouperFunc(func(domain string) string {
+		domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+		registrable, err := publicsuffix.EffectiveTLDPlusOne(domain)
+		if err != nil {
+			return domain
+		}
+		return registrable
+	})
+}
+
+// ByPatterns groups domains matching any of the patterns into the named group.
+// A pattern is a domain, or a wildcard such as "*.example.com" that matches all subdomains.
+func ByPatterns(name string, patterns ...string) Grouper {
+	return GrouperFunc(func(domain string) string {
+		domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+		for _, pattern := range patterns {
+			pattern = strings.ToLower(pattern)
+			if domain == pattern {
+				return name
+			}
+			if parent, ok := strings.CutPrefix(pattern, "*."); ok && strings.HasSuffix(domain, "."+parent) {
+				return name
+			}
+		}
+		return ""
+	})
+}
+
+// ByRegexp groups domains matching re into the group named by template,
+// which may refer to submatches as in regexp.Regexp.Expand, e.g. "$1".
+func ByRegexp(re *regexp.Regexp, template string) Grouper {
+	return GrouperFunc(func(domain string) string {
+		match := re.FindStringSubmatchIndex(domain)
+		if match == nil {
+			return ""
+		}
+		return string(re.ExpandString(nil, template, domain, match))
+	})
+}
+
+// FirstOf returns the group of the first grouper that matches a domain.
+func FirstOf(groupers ...Grouper) Grouper {
+	return GrouperFunc(func(domain string) string {
+		for _, g := range groupers {
+			if name := g.Group(domain); name != "" {
+				return name
+			}
+		}
+		return ""
+	})
+}
+
+// DomainGroup is page views of the domains in a group.
+type DomainGroup struct {
+	Name  string
+	Value int
+	// Domains is the domains in the group in the order they appeared.
+	Domains []string
+}
+
+// GroupDomains sums the values of the results by group.
+// Domains that the grouper does not match are grouped into Ungrouped.
+// The groups are ordered by value, descending, and by name for the same value.
+func GroupDomains(results []*DomainsListResult, grouper Grouper) []*DomainGroup {
+	var (
+		groups []*DomainGroup
+		index  = map[string]*DomainGroup{}
+		seen   = map[[2]string]bool{}
+	)
+	for _, r := range results {
+		name := grouper.Group(r.Domain)
+		if name == "" {
+			name = Ungrouped
+		}
+
+		g, ok := index[name]
+		if !ok {
+			g = &DomainGroup{Name: name}
+			index[name] = g
+			groups = append(groups, g)
+		}
+		g.Value += r.Value
+		if !seen[[2]string{name, r.Domain}] {
+			seen[[2]string{name, r.Domain}] = true
+			g.Domains = append(g.Domains, r.Domain)
+		}
+	}
+
+	slices.SortFunc(groups, func(a, b *DomainGroup) int {
+		return cmp.Or(
+			cmp.Compare(b.Value, a.Value),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+	return groups
+}
+
+// DomainsGroupResponse is page views of a period grouped by domain.
+type DomainsGroupResponse struct {
+	Groups []*DomainGroup
+	Meta   *DomainsListMetadata
+}
+
+// Group retrieves page view statistics of all domains with ListAll and groups them by the grouper.
+func (d *Domains) Group(
+	ctx context.Context,
+	input *DomainsListInput,
+	grouper Grouper,
+	options ...option.Option,
+) (*DomainsGroupResponse, error) {
+	result, err := d.ListAll(ctx, input, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &DomainsGroupResponse{
+		Groups: GroupDomains(result.Domains, grouper),
+		Meta:   result.Meta,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package stats
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/pager"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByRegistrableDomain(t *testing.T) {
+	g := ByRegistrableDomain()
+
+	assert.Equal(t, "example.com", g.Group("www.example.com"))
+	assert.Equal(t, "example.com", g.Group("Staging.M.Example.com."))
+	assert.Equal(t, "example.co.jp", g.Group("www.example.co.jp"))
+	assert.Equal(t, "co.jp", g.Group("co.jp"))
+	assert.Equal(t, "localhost", g.Group("localhost"))
+}
+
+func TestByPatterns(t *testing.T) {
+	g := ByPatterns("shop", "shop.example.com", "*.shop.example.com")
+
+	assert.Equal(t, "shop", g.Group("shop.example.com"))
+	assert.Equal(t, "shop", g.Group("m.shop.example.com"))
+	assert.Empty(t, g.Group("example.com"))
+	assert.Empty(t, g.Group("myshop.example.com"))
+}
+
+func TestByRegexp(t *testing.T) {
+	g := ByRegexp(regexp.MustCompile(`^(?:[^.]+\.)*(\w+)\.example\.net$`), "brand-$1")
+
+	assert.Equal(t, "brand-foo", g.Group("www.foo.example.net"))
+	assert.Equal(t, "brand-bar", g.Group("bar.example.net"))
+	assert.Empty(t, g.Group("example.net"))
+}
+
+func TestGroupDomains(t *testing.T) {
+	results := []*DomainsListResult{
+		{Domain: "www.example.com", Value: 100},
+		{Domain: "m.shop.example.com", Value: 50},
+		{Domain: "staging.example.com", Value: 10},
+		{Domain: "shop.example.com", Value: 200},
+		{Domain: "other.test", Value: 5},
+	}
+	grouper := FirstOf(
+		ByPatterns("shop", "shop.example.com", "*.shop.example.com"),
+		ByPatterns("corporate", "*.example.com"),
+	)
+
+	assert.Equal(t, []*DomainGroup{
+		{Name: "shop", Value: 250, Domains: []string{"m.shop.example.com", "shop.example.com"}},
+		{Name: "corporate", Value: 110, Domains: []string{"www.example.com", "staging.example.com"}},
+		{Name: Ungrouped, Value: 5, Domains: []string{"other.test"}},
+	}, GroupDomains(results, grouper))
+
+	assert.Equal(t, []*DomainGroup{
+		{Name: "example.com", Value: 360, Domains: []string{"www.example.com", "m.shop.example.com", "staging.example.com", "shop.example.com"}},
+		{Name: "other.test", Value: 5, Domains: []string{"other.test"}},
+	}, GroupDomains(results, ByRegistrableDomain()))
+}
+
+func TestDomains_Group(t *testing.T) {
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv/domains",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, pager.Page[*DomainsListResult, *DomainsListMetadata]{
+			Result: []*DomainsListResult{
+				{Domain: "www.example.com", Value: 100},
+				{Domain: "example.com", Value: 20},
+				{Domain: "www.example.org", Value: 30},
+			},
+			Meta: &DomainsListMetadata{ProjectID: "project", From: "2025-07", To: "2025-07"},
+		}),
+	)
+
+	c := clienttest.NewClient(t)
+	domains := NewDomains(c)
+
+	result, err := domains.Group(t.Context(), &DomainsListInput{Period: lo.ToPtr(NewMonth(2025, 7).Period())}, ByRegistrableDomain())
+
+	assert.NoError(t, err)
+	assert.Equal(t, &DomainsGroupResponse{
+		Groups: []*DomainGroup{
+			{Name: "example.com", Value: 120, Domains: []string{"www.example.com", "example.com"}},
+			{Name: "example.org", Value: 30, Domains: []string{"www.example.org"}},
+		},
+		Meta: &DomainsListMetadata{ProjectID: "project", From: "2025-07", To: "2025-07"},
+	}, result)
+}
@@ -0,0 +1,194 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const monthLayout = "2006-01"
+
+var (
+	// Location is the time zone of the service, in which months are interpreted.
+	Location = time.FixedZone("JST", 9*60*60)
+
+	// ErrInvalidPeriod is returned when a period is not accepted by the API.
+	ErrInvalidPeriod = errors.New("invalid period")
+
+	_ error = (*PeriodError)(nil)
+
+	now = time.Now
+)
+
+// Month is a calendar month in the service's time zone,
+// the unit in which the API reports statistics.
+type Month struct {
+	Year  int
+	Month time.Month
+}
+
+// NewMonth returns the month of the year.
+func NewMonth(year int, month time.Month) Month {
+	return Month{Year: year, Month: month}
+}
+
+// MonthOf returns the month containing t in the service's time zone.
+func MonthOf(t time.Time) Month {
+	year, month, _ := t.In(Location).Date()
+	return NewMonth(year, month)
+}
+
+// CurrentMonth returns the current month in the service's time zone.
+func CurrentMonth() Month {
+	return MonthOf(now())
+}
+
+// ParseMonth parses a month formatted as "2006-01".
+func ParseMonth(s string) (Month, error) {
+	t, err := time.Parse(monthLayout, s)
+	if err != nil {
+		return Month{}, fmt.Errorf("%w: malformed month: %q", ErrInvalidPeriod, s)
+	}
+	return NewMonth(t.Year(), t.Month()), nil
+}
+
+// String formats the month as "2006-01".
+func (m Month) String() string {
+	return m.Time().Format(monthLayout)
+}
+
+// MarshalText formats the month as String does.
+func (m Month) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText parses the month as ParseMonth does.
+func (m *Month) UnmarshalText(text []byte) error {
+	month, err := ParseMonth(string(text))
+	if err != nil {
+		return err
+	}
+	*m = month
+	return nil
+}
+
+// IsZero returns true if the month is not set.
+func (m Month) IsZero() bool {
+	return m == Month{}
+}
+
+// Valid returns true if the month exists in the calendar.
+func (m Month) Valid() bool {
+	return m.Month >= time.January && m.Month <= time.December
+}
+
+// Time returns the start of the month in the service's time zone.
+func (m Month) Time() time.Time {
+	return time.Date(m.Year, m.Month, 1, 0, 0, 0, 0, Location)
+}
+
+// AddMonths returns the month n months after the month.
+func (m Month) AddMonths(n int) Month {
+	return MonthOf(m.Time().AddDate(0, n, 0))
+}
+
+// Compare returns -1, 0 or +1 depending on whether m is before, equal to or after other.
+func (m Month) Compare(other Month) int {
+	return m.Time().Compare(other.Time())
+}
+
+// Before returns true if m is before other.
+func (m Month) Before(other Month) bool {
+	return m.Compare(other) < 0
+}
+
+// After returns true if m is after other.
+func (m Month) After(other Month) bool {
+	return m.Compare(other) > 0
+}
+
+// Period returns the period of the month alone.
+func (m Month) Period() Period {
+	return NewPeriod(m, m)
+}
+
+// Period is an inclusive range of months.
+// The API takes from and to formatted as "2006-01" and does not accept days.
+type Period struct {
+	From Month
+	To   Month
+}
+
+// NewPeriod returns the period from and to the months, inclusive.
+func NewPeriod(from, to Month) Period {
+	return Period{From: from, To: to}
+}
+
+// ThisMonth returns the period of the current month.
+func ThisMonth() Period {
+	return CurrentMonth().Period()
+}
+
+// LastMonth returns the period of the previous month.
+func LastMonth() Period {
+	return CurrentMonth().AddMonths(-1).Period()
+}
+
+// LastNMonths returns the period of the last n months including the current month.
+func LastNMonths(n int) Period {
+	m := CurrentMonth()
+	return NewPeriod(m.AddMonths(1-n), m)
+}
+
+// String formats the period as "from/to".
+func (p Period) String() string {
+	return fmt.Sprintf("%s/%s", p.From, p.To)
+}
+
+// Validate returns a *PeriodError if the period is not accepted by the API.
+func (p Period) Validate() error {
+	invalid := func(reason string) error {
+		return &PeriodError{Period: p, Reason: reason}
+	}
+
+	switch {
+	case p.From.IsZero() || p.To.IsZero():
+		return invalid("from and to are required")
+	case !p.From.Valid() || !p.To.Valid():
+		return invalid("month does not exist")
+	case p.To.Before(p.From):
+		return invalid("from is after to")
+	case p.To.After(CurrentMonth()):
+		return invalid("to is in the future")
+	}
+	return nil
+}
+
+// PeriodError represents a period that is not accepted by the API.
+type PeriodError struct {
+	Period Period
+	Reason string
+}
+
+// Error returns the error message for the PeriodError.
+func (err *PeriodError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", ErrInvalidPeriod, err.Period, err.Reason)
+}
+
+// Unwrap returns ErrInvalidPeriod.
+func (err *PeriodError) Unwrap() error {
+	return ErrInvalidPeriod
+}
+
+// parsePeriod parses the from and to values returned by the API.
+func parsePeriod(from, to string) (Period, error) {
+	f, err := ParseMonth(from)
+	if err != nil {
+		return Period{}, err
+	}
+	t, err := ParseMonth(to)
+	if err != nil {
+		return Period{}, err
+	}
+	return NewPeriod(f, t), nil
+}
@@ -0,0 +1,122 @@
+package stats
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setNow(t *testing.T, year int, month time.Month, day int) {
+	t.Helper()
+
+	original := now
+	now = func() time.Time {
+		return time.Date(year, month, day, 12, 0, 0, 0, Location)
+	}
+	t.Cleanup(func() {
+		now = original
+	})
+}
+
+func TestParseMonth(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Month
+		wantErr bool
+	}{
+		{"2025-09", NewMonth(2025, time.September), false},
+		{"2025-9", Month{}, true},
+		{"2025-09-30", Month{}, true},
+		{"2025-13", Month{}, true},
+		{"", Month{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseMonth(tt.input)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPeriod)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.input, got.String())
+		})
+	}
+}
+
+func TestMonthOf(t *testing.T) {
+	// 2025-09-30T16:00:00Z is 2025-10-01 in the service's time zone
+	assert.Equal(t, NewMonth(2025, time.October), MonthOf(time.Date(2025, 9, 30, 16, 0, 0, 0, time.UTC)))
+}
+
+func TestMonth_json(t *testing.T) {
+	raw, err := json.Marshal(NewPeriod(NewMonth(2025, time.August), NewMonth(2025, time.September)))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"From":"2025-08","To":"2025-09"}`, string(raw))
+
+	var months []Month
+	assert.NoError(t, json.Unmarshal([]byte(`["2025-08","2025-09"]`), &months))
+	assert.Equal(t, []Month{NewMonth(2025, time.August), NewMonth(2025, time.September)}, months)
+
+	assert.ErrorIs(t, json.Unmarshal([]byte(`["2025-09-30"]`), &months), ErrInvalidPeriod)
+}
+
+func TestMonth_arithmetic(t *testing.T) {
+	assert.Equal(t, NewMonth(2024, time.December), NewMonth(2025, time.January).AddMonths(-1))
+	assert.Equal(t, NewMonth(2026, time.February), NewMonth(2025, time.January).AddMonths(13))
+	assert.True(t, NewMonth(2024, time.December).Before(NewMonth(2025, time.January)))
+	assert.True(t, NewMonth(2025, time.September).After(NewMonth(2025, time.August)))
+}
+
+func TestPeriod_helpers(t *testing.T) {
+	setNow(t, 2026, time.January, 15)
+
+	assert.Equal(t, "2026-01/2026-01", ThisMonth().String())
+	assert.Equal(t, "2025-12/2025-12", LastMonth().String())
+	assert.Equal(t, "2025-11/2026-01", LastNMonths(3).String())
+	assert.Equal(t, "2026-09/2026-09", NewMonth(2026, 9).Period().String())
+}
+
+func TestPeriod_Validate(t *testing.T) {
+	setNow(t, 2025, time.September, 15)
+
+	tests := []struct {
+		name       string
+		period     Period
+		wantReason string
+	}{
+		{"months", NewPeriod(NewMonth(2025, 8), NewMonth(2025, 9)), ""},
+		{"missing", NewPeriod(Month{}, NewMonth(2025, 9)), "from and to are required"},
+		{"nonexistent", NewPeriod(NewMonth(2025, 13), NewMonth(2025, 13)), "month does not exist"},
+		{"reversed", NewPeriod(NewMonth(2025, 9), NewMonth(2025, 8)), "from is after to"},
+		{"future", NewPeriod(NewMonth(2025, 9), NewMonth(2025, 10)), "to is in the future"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.period.Validate()
+
+			if tt.wantReason == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var periodErr *PeriodError
+			if assert.ErrorAs(t, err, &periodErr) {
+				assert.Equal(t, tt.wantReason, periodErr.Reason)
+			}
+			assert.ErrorIs(t, err, ErrInvalidPeriod)
+		})
+	}
+}
+
+func TestPVGetMetadata_Period(t *testing.T) {
+	meta := &PVGetMetadata{From: "2025-08", To: "2025-09"}
+
+	period, err := meta.Period()
+
+	assert.NoError(t, err)
+	assert.Equal(t, NewPeriod(NewMonth(2025, 8), NewMonth(2025, 9)), period)
+}
@@ -2,14 +2,19 @@ package stats
 
 import (
 	"context"
+	"errors"
 
 	"github.com/morisawa-inc/morisawafonts-webfont-go/client"
 	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
 )
 
+// ErrNoPV is returned when a response of page views has no pv object.
+var ErrNoPV = errors.New("stats: response has no page views")
+
 // PV retrieves page view statistics.
 type PV struct {
 	client  *client.Client
+	cache   cache
 	Domains *Domains
 }
 
@@ -22,15 +27,35 @@ func NewPV(c *client.Client) *PV {
 }
 
 // Get retrieves page view statistics for the project.
+// A *PeriodError is returned before any request if the input period is invalid.
+// If the period is split by option.WithMaxRange or clipped by option.WithMaxAge,
+// the totals of the windows are summed and the windows are reported in the metadata.
+// ErrNoPV is returned if the response has no page views, so that PV of the result is never nil.
 func (p *PV) Get(
 	ctx context.Context,
 	input *PVGetInput,
 	options ...option.Option,
 ) (*PVGetResponse, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	if input != nil {
+		ws, err := windows(input.Period, p.client.Options(options...))
+		if err != nil {
+			return nil, err
+		}
+		if ws != nil {
+			return p.getWindows(ctx, *input.Period, ws, options...)
+		}
+	}
+
 	var result PVGetResponse
 	err := p.client.Get(ctx, "/stats/pv", input.Values(), &result, options...)
 	if err != nil {
 		return nil, err
 	}
+	if result.PV == nil {
+		return nil, ErrNoPV
+	}
 	return &result, nil
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/jarcoal/httpmock"
 	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
@@ -52,3 +53,72 @@ func TestPV_Get(t *testing.T) {
 	}, result)
 	assert.NoError(t, err)
 }
+
+func TestPV_Get_period(t *testing.T) {
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "2025-07", req.URL.Query().Get("from"))
+			assert.Equal(t, "2025-08", req.URL.Query().Get("to"))
+
+			return httpmock.NewJsonResponse(http.StatusOK, &PVGetResponse{
+				PV: &PVGetResult{
+					Total: 1234,
+				},
+			})
+		},
+	)
+
+	c := clienttest.NewClient(t)
+	pv := NewPV(c)
+
+	result, err := pv.Get(t.Context(), &PVGetInput{
+		From:   lo.ToPtr("ignored"),
+		Period: &Period{From: NewMonth(2025, 7), To: NewMonth(2025, 8)},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1234, result.PV.Total)
+}
+
+func TestPV_Get_invalidPeriod(t *testing.T) {
+	httpmock.Activate(t)
+
+	c := clienttest.NewClient(t)
+	pv := NewPV(c)
+
+	result, err := pv.Get(t.Context(), &PVGetInput{
+		Period: &Period{From: NewMonth(2025, 9), To: NewMonth(2025, 8)},
+	})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrInvalidPeriod)
+	assert.Zero(t, httpmock.GetTotalCallCount())
+}
+
+func TestPV_Get_noPV(t *testing.T) {
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv",
+		httpmock.NewStringResponder(http.StatusOK, `{"meta":{"project_id":"project"}}`),
+	)
+
+	c := clienttest.NewClient(t)
+	pv := NewPV(c)
+
+	result, err := pv.Get(t.Context(), &PVGetInput{Period: lo.ToPtr(NewMonth(2025, 8).Period())})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrNoPV)
+
+	// Split periods are not summed as zero either.
+	result, err = pv.Get(t.Context(), &PVGetInput{
+		Period: lo.ToPtr(NewPeriod(NewMonth(2025, 7), NewMonth(2025, 8))),
+	}, option.WithMaxRange(1))
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrNoPV)
+}
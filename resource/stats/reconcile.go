@@ -0,0 +1,101 @@
+package stats
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/client"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/parallel"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/resource/domain"
+)
+
+// Reconciliation is page views of a period joined with the registered domains.
+type Reconciliation struct {
+	ProjectID string
+	Period    Period
+
+	// Registered is the page views of each registered domain, including the hosts it covers,
+	// ordered by value, descending.
+	Registered []*DomainUsage
+	// Unused is the registered domains without page views, which are cleanup candidates.
+	Unused []string
+	// Unregistered is the hosts with page views that no registered domain covers,
+	// ordered by value, descending.
+	Unregistered []*DomainsListResult
+}
+
+// DomainUsage is page views of a registered domain.
+type DomainUsage struct {
+	Domain string
+	Value  int
+	// Hosts is the hosts with page views covered by the domain.
+	Hosts []string
+}
+
+// Reconcile joins page views of the period with the registered domains.
+// A host is covered by a registered domain as in domain.Set.Match,
+// so a wildcard or a parent domain covers its subdomains.
+func Reconcile(
+	ctx context.Context,
+	c *client.Client,
+	period Period,
+	options ...option.Option,
+) (*Reconciliation, error) {
+	if err := period.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		set   *domain.Set
+		views *DomainsListAllResponse
+	)
+	err := parallel.ForEach(ctx, 2, c.Options(options...).Concurrency, func(ctx context.Context, i int) error {
+		var err error
+		if i == 0 {
+			set, err = domain.NewDomains(c).LoadSet(ctx, options...)
+		} else {
+			views, err = NewDomains(c).ListAll(ctx, &DomainsListInput{Period: &period}, options...)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Reconciliation{
+		ProjectID: views.Meta.ProjectID,
+		Period:    period,
+	}
+	usages := map[string]*DomainUsage{}
+	for _, result := range views.Domains {
+		registered, ok := set.Match(result.Domain)
+		if !ok {
+			r.Unregistered = append(r.Unregistered, result)
+			continue
+		}
+
+		usage, ok := usages[registered]
+		if !ok {
+			usage = &DomainUsage{Domain: registered}
+			usages[registered] = usage
+		}
+		usage.Value += result.Value
+		usage.Hosts = append(usage.Hosts, result.Domain)
+	}
+
+	for _, registered := range set.Domains() {
+		usage, ok := usages[registered]
+		if !ok || usage.Value == 0 {
+			r.Unused = append(r.Unused, registered)
+		}
+		if ok {
+			r.Registered = append(r.Registered, usage)
+		}
+	}
+	slices.SortStableFunc(r.Registered, func(a, b *DomainUsage) int {
+		return cmp.Compare(b.Value, a.Value)
+	})
+	return r, nil
+}
@@ -0,0 +1,71 @@
+package stats
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/pager"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/resource/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcile(t *testing.T) {
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/domains",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, pager.Page[string, *domain.ListMetadata]{
+			Result: []string{"example.com", "*.shop.example.net", "unused.example.org", "zero.example.org"},
+			Meta:   &domain.ListMetadata{ProjectID: "project"},
+		}),
+	)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv/domains",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, pager.Page[*DomainsListResult, *DomainsListMetadata]{
+			Result: []*DomainsListResult{
+				{Domain: "www.shop.example.net", Value: 500},
+				{Domain: "example.com", Value: 300},
+				{Domain: "unknown.test", Value: 200},
+				{Domain: "www.example.com", Value: 100},
+				{Domain: "shop.example.net", Value: 50},
+				{Domain: "zero.example.org", Value: 0},
+			},
+			Meta: &DomainsListMetadata{ProjectID: "project", From: "2025-07", To: "2025-07"},
+		}),
+	)
+
+	c := clienttest.NewClient(t)
+
+	r, err := Reconcile(t.Context(), c, NewMonth(2025, 7).Period())
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Reconciliation{
+		ProjectID: "project",
+		Period:    NewMonth(2025, 7).Period(),
+		Registered: []*DomainUsage{
+			{Domain: "*.shop.example.net", Value: 500, Hosts: []string{"www.shop.example.net"}},
+			{Domain: "example.com", Value: 400, Hosts: []string{"example.com", "www.example.com"}},
+			{Domain: "zero.example.org", Value: 0, Hosts: []string{"zero.example.org"}},
+		},
+		Unused: []string{"unused.example.org", "zero.example.org"},
+		Unregistered: []*DomainsListResult{
+			{Domain: "unknown.test", Value: 200},
+			{Domain: "shop.example.net", Value: 50},
+		},
+	}, r)
+}
+
+func TestReconcile_invalidPeriod(t *testing.T) {
+	httpmock.Activate(t)
+
+	c := clienttest.NewClient(t)
+
+	r, err := Reconcile(t.Context(), c, NewPeriod(NewMonth(2025, 8), NewMonth(2025, 7)))
+
+	assert.Nil(t, r)
+	assert.ErrorIs(t, err, ErrInvalidPeriod)
+	assert.Zero(t, httpmock.GetTotalCallCount())
+}
@@ -0,0 +1,141 @@
+package stats
+
+import (
+	"context"
+	"sync"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/parallel"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+)
+
+// Series is the monthly page views of a period in chronological order.
+// Buckets are whole months because the API accepts periods of whole months only.
+type Series struct {
+	ProjectID string
+	Period    Period
+	Buckets   []*Bucket
+}
+
+// Bucket is the page views of a month of a series.
+type Bucket struct {
+	Period Period
+	Total  int
+}
+
+// Split divides the period into the period of each month.
+// The last one is the month of p.To, so a period ending in the current month has no future months.
+func (p Period) Split() []Period {
+	var periods []Period
+	for m := p.From; !m.After(p.To); m = m.AddMonths(1) {
+		periods = append(periods, m.Period())
+	}
+	return periods
+}
+
+// Series retrieves page views for each month of the period.
+// Up to option.WithConcurrency buckets are fetched at once,
+// and buckets of months before the current month are cached, while the current month is always fetched.
+func (p *PV) Series(
+	ctx context.Context,
+	period Period,
+	options ...option.Option,
+) (*Series, error) {
+	if err := period.Validate(); err != nil {
+		return nil, err
+	}
+
+	periods := period.Split()
+	series := &Series{
+		Period:  period,
+		Buckets: make([]*Bucket, len(periods)),
+	}
+
+	var mu sync.Mutex
+	err := parallel.ForEach(ctx, len(periods), p.client.Options(options...).Concurrency, func(ctx context.Context, i int) error {
+		total, projectID, err := p.total(ctx, periods[i], options...)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		series.Buckets[i] = &Bucket{Period: periods[i], Total: total}
+		if projectID != "" {
+			series.ProjectID = projectID
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// total returns the page views of the period and the project ID, using the cache for closed periods.
+func (p *PV) total(
+	ctx context.Context,
+	period Period,
+	options ...option.Option,
+) (int, string, error) {
+	o := p.client.Options(options...)
+	key := cacheKey{token: o.APIToken, period: period}
+	if o.BaseURL != nil {
+		key.baseURL = o.BaseURL.String()
+	}
+	closed := period.To.Before(CurrentMonth())
+	if closed {
+		if entry, ok := p.cache.get(key); ok {
+			return entry.total, entry.projectID, nil
+		}
+	}
+
+	result, err := p.Get(ctx, &PVGetInput{Period: &period}, options...)
+	if err != nil {
+		return 0, "", err
+	}
+
+	entry := cacheEntry{total: result.PV.Total}
+	if result.Meta != nil {
+		entry.projectID = result.Meta.ProjectID
+	}
+	if closed {
+		p.cache.set(key, entry)
+	}
+	return entry.total, entry.projectID, nil
+}
+
+// cache keeps the page views of closed periods per API token and base URL.
+type cache struct {
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+}
+
+type cacheKey struct {
+	token   string
+	baseURL string
+	period  Period
+}
+
+type cacheEntry struct {
+	total     int
+	projectID string
+}
+
+func (c *cache) get(key cacheKey) (cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+func (c *cache) set(key cacheKey, entry cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = map[cacheKey]cacheEntry{}
+	}
+	c.entries[key] = entry
+}
@@ -0,0 +1,175 @@
+package stats
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeriod_Split(t *testing.T) {
+	tests := []struct {
+		name     string
+		period   Period
+		expected []string
+	}{
+		{
+			name:     "months",
+			period:   NewPeriod(NewMonth(2024, 12), NewMonth(2025, 2)),
+			expected: []string{"2024-12/2024-12", "2025-01/2025-01", "2025-02/2025-02"},
+		},
+		{
+			name:     "single month",
+			period:   NewMonth(2025, 9).Period(),
+			expected: []string{"2025-09/2025-09"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actual []string
+			for _, p := range tt.period.Split() {
+				actual = append(actual, p.String())
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func setupSeriesMock(t *testing.T) *[]string {
+	t.Helper()
+
+	var (
+		mu        sync.Mutex
+		requested []string
+	)
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv",
+		func(req *http.Request) (*http.Response, error) {
+			from := req.URL.Query().Get("from")
+			to := req.URL.Query().Get("to")
+			if strings.HasPrefix(from, "2025-06") {
+				return httpmock.NewStringResponse(http.StatusInternalServerError, ""), nil
+			}
+			mu.Lock()
+			requested = append(requested, from+"/"+to)
+			mu.Unlock()
+
+			// The total is the month to tell buckets apart.
+			month, _ := ParseMonth(from)
+			return httpmock.NewJsonResponse(http.StatusOK, &PVGetResponse{
+				PV:   &PVGetResult{Total: int(month.Month) * 10},
+				Meta: &PVGetMetadata{ProjectID: "project", From: from, To: to},
+			})
+		},
+	)
+	return &requested
+}
+
+func TestPV_Series(t *testing.T) {
+	setNow(t, 2025, 8, 20)
+	requested := setupSeriesMock(t)
+
+	c := clienttest.NewClient(t, option.WithConcurrency(4))
+	pv := NewPV(c)
+
+	period := NewPeriod(NewMonth(2025, 7), NewMonth(2025, 8))
+	series, err := pv.Series(t.Context(), period)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Series{
+		ProjectID: "project",
+		Period:    period,
+		Buckets: []*Bucket{
+			{Period: NewMonth(2025, 7).Period(), Total: 70},
+			{Period: NewMonth(2025, 8).Period(), Total: 80},
+		},
+	}, series)
+	assert.ElementsMatch(t, []string{"2025-07/2025-07", "2025-08/2025-08"}, *requested)
+
+	// Closed months are cached, and the current month is fetched again.
+	*requested = nil
+	series, err = pv.Series(t.Context(), period)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "project", series.ProjectID)
+	assert.Len(t, series.Buckets, 2)
+	assert.Equal(t, []string{"2025-08/2025-08"}, *requested)
+
+	// The project ID is kept with the cached totals.
+	*requested = nil
+	series, err = pv.Series(t.Context(), NewMonth(2025, 7).Period())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "project", series.ProjectID)
+	assert.Empty(t, *requested)
+
+	// Another API endpoint does not share the cache.
+	staging, _ := url.Parse("https://staging.example.com/webfont/v1")
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://staging.example.com/webfont/v1/stats/pv",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, &PVGetResponse{
+			PV:   &PVGetResult{Total: 1},
+			Meta: &PVGetMetadata{ProjectID: "staging", From: "2025-07", To: "2025-07"},
+		}),
+	)
+	series, err = pv.Series(t.Context(), NewMonth(2025, 7).Period(), option.WithBaseURL(staging))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "staging", series.ProjectID)
+	assert.Equal(t, []*Bucket{{Period: NewMonth(2025, 7).Period(), Total: 1}}, series.Buckets)
+}
+
+func TestPV_Series_thisMonth(t *testing.T) {
+	setNow(t, 2025, 9, 3)
+	requested := setupSeriesMock(t)
+
+	c := clienttest.NewClient(t)
+	pv := NewPV(c)
+
+	series, err := pv.Series(t.Context(), ThisMonth())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*Bucket{{Period: NewMonth(2025, 9).Period(), Total: 90}}, series.Buckets)
+
+	// The current month is never cached while its page views are still growing.
+	series, err = pv.Series(t.Context(), ThisMonth())
+
+	assert.NoError(t, err)
+	assert.Len(t, series.Buckets, 1)
+	assert.Equal(t, []string{"2025-09/2025-09", "2025-09/2025-09"}, *requested)
+}
+
+func TestPV_Series_error(t *testing.T) {
+	setNow(t, 2025, 8, 20)
+	setupSeriesMock(t)
+
+	c := clienttest.NewClient(t, option.WithConcurrency(2))
+	pv := NewPV(c)
+
+	series, err := pv.Series(t.Context(), NewPeriod(NewMonth(2025, 5), NewMonth(2025, 7)))
+
+	assert.Nil(t, series)
+	assert.Error(t, err)
+}
+
+func TestPV_Series_invalidPeriod(t *testing.T) {
+	httpmock.Activate(t)
+
+	c := clienttest.NewClient(t)
+	pv := NewPV(c)
+
+	series, err := pv.Series(t.Context(), NewPeriod(NewMonth(2025, 9), NewMonth(2025, 8)))
+
+	assert.Nil(t, series)
+	assert.ErrorIs(t, err, ErrInvalidPeriod)
+	assert.Zero(t, httpmock.GetTotalCallCount())
+}
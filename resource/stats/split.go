@@ -0,0 +1,212 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/parallel"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+)
+
+// Windows divides the period into consecutive windows of at most span months.
+// The period itself is returned if span is not positive.
+func (p Period) Windows(span int) []Period {
+	if span <= 0 {
+		return []Period{p}
+	}
+
+	var windows []Period
+	for from := p.From; !from.After(p.To); from = from.AddMonths(span) {
+		to := from.AddMonths(span - 1)
+		if to.After(p.To) {
+			to = p.To
+		}
+		windows = append(windows, NewPeriod(from, to))
+	}
+	return windows
+}
+
+// windows returns the windows of the period if it must be split by option.WithMaxRange
+// or clipped by option.WithMaxAge. A *PeriodError is returned if the period is entirely
+// older than the maximum age.
+func windows(period *Period, o *option.ClientOptions) ([]Period, error) {
+	if period == nil {
+		return nil, nil
+	}
+
+	p, clipped := *period, false
+	if o.MaxAge > 0 {
+		oldest := CurrentMonth().AddMonths(1 - o.MaxAge)
+		if p.To.Before(oldest) {
+			return nil, &PeriodError{Period: p, Reason: fmt.Sprintf("older than %d months", o.MaxAge)}
+		}
+		if p.From.Before(oldest) {
+			p.From, clipped = oldest, true
+		}
+	}
+
+	windows := p.Windows(o.MaxRange)
+	if len(windows) < 2 && !clipped {
+		return nil, nil
+	}
+	return windows, nil
+}
+
+// getWindows retrieves page views for each window and sums them.
+func (p *PV) getWindows(
+	ctx context.Context,
+	period Period,
+	windows []Period,
+	options ...option.Option,
+) (*PVGetResponse, error) {
+	totals := make([]int, len(windows))
+	projectIDs := make([]string, len(windows))
+
+	err := parallel.ForEach(ctx, len(windows), p.client.Options(options...).Concurrency, func(ctx context.Context, i int) error {
+		result, err := p.Get(ctx, &PVGetInput{Period: &windows[i]}, options...)
+		if err != nil {
+			return err
+		}
+
+		totals[i] = result.PV.Total
+		if result.Meta != nil {
+			projectIDs[i] = result.Meta.ProjectID
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var total int
+	for _, t := range totals {
+		total += t
+	}
+	return &PVGetResponse{
+		PV: &PVGetResult{Total: total},
+		Meta: &PVGetMetadata{
+			ProjectID: projectID(projectIDs),
+			From:      period.From.String(),
+			To:        period.To.String(),
+			Windows:   windows,
+		},
+	}, nil
+}
+
+// windowInputs returns the input of each window, or the input itself if the period is not split.
+// The cursor of the input is cleared in the windows because it belongs to the query of the whole period.
+func windowInputs(in *DomainsListInput, ws []Period) []*DomainsListInput {
+	if ws == nil {
+		return []*DomainsListInput{in}
+	}
+	inputs := make([]*DomainsListInput, len(ws))
+	for i := range ws {
+		window := *in
+		window.Period = &ws[i]
+		window.Cursor = nil
+		inputs[i] = &window
+	}
+	return inputs
+}
+
+// DomainsListAllResponse is page view statistics of all domains in a period.
+type DomainsListAllResponse struct {
+	Domains []*DomainsListResult
+	Meta    *DomainsListMetadata
+}
+
+// ListAll retrieves page view statistics of all domains, following every page.
+// If the period is split by option.WithMaxRange or clipped by option.WithMaxAge,
+// the values of each domain are summed across the windows.
+// The domains are ordered by value, descending.
+func (d *Domains) ListAll(
+	ctx context.Context,
+	input *DomainsListInput,
+	options ...option.Option,
+) (*DomainsListAllResponse, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	var in DomainsListInput
+	if input != nil {
+		in = *input
+	}
+	ws, err := windows(in.Period, d.client.Options(options...))
+	if err != nil {
+		return nil, err
+	}
+	inputs := windowInputs(&in, ws)
+
+	results := make([][]*DomainsListResult, len(inputs))
+	metas := make([]*DomainsListMetadata, len(inputs))
+	err = parallel.ForEach(ctx, len(inputs), d.client.Options(options...).Concurrency, func(ctx context.Context, i int) error {
+		p := d.List(inputs[i], options...)
+		for p.HasNextPage() {
+			page, err := p.GetNextPage(ctx)
+			if err != nil {
+				return err
+			}
+
+			results[i] = append(results[i], page.Result...)
+			metas[i] = page.Meta
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &DomainsListMetadata{}
+	if metas[0] != nil {
+		meta.ProjectID, meta.From, meta.To = metas[0].ProjectID, metas[0].From, metas[0].To
+	}
+	if ws != nil {
+		projectIDs := make([]string, len(metas))
+		for i, m := range metas {
+			if m != nil {
+				projectIDs[i] = m.ProjectID
+			}
+		}
+		meta.ProjectID = projectID(projectIDs)
+		meta.From, meta.To = in.Period.From.String(), in.Period.To.String()
+		meta.Windows = ws
+	}
+
+	return &DomainsListAllResponse{
+		Domains: merge(slices.Concat(results...)),
+		Meta:    meta,
+	}, nil
+}
+
+// merge sums the values of the same domain and orders the domains by value, descending.
+func merge(results []*DomainsListResult) []*DomainsListResult {
+	var (
+		merged []*DomainsListResult
+		index  = map[string]int{}
+	)
+	for _, r := range results {
+		if i, ok := index[r.Domain]; ok {
+			merged[i].Value += r.Value
+			continue
+		}
+		index[r.Domain] = len(merged)
+		merged = append(merged, &DomainsListResult{Domain: r.Domain, Value: r.Value})
+	}
+
+	slices.SortStableFunc(merged, func(a, b *DomainsListResult) int {
+		return b.Value - a.Value
+	})
+	return merged
+}
+
+// projectID returns the first non-empty project ID.
+func projectID(projectIDs []string) string {
+	for _, id := range projectIDs {
+		if id != "" {
+			return id
+		}
+	}
+	return ""
+}
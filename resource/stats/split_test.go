@@ -0,0 +1,275 @@
+package stats
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/pager"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeriod_Windows(t *testing.T) {
+	tests := []struct {
+		name     string
+		period   Period
+		span     int
+		expected []string
+	}{
+		{
+			name:     "months",
+			period:   NewPeriod(NewMonth(2024, 11), NewMonth(2025, 3)),
+			span:     2,
+			expected: []string{"2024-11/2024-12", "2025-01/2025-02", "2025-03/2025-03"},
+		},
+		{
+			name:     "within span",
+			period:   NewPeriod(NewMonth(2025, 6), NewMonth(2025, 8)),
+			span:     3,
+			expected: []string{"2025-06/2025-08"},
+		},
+		{
+			name:     "disabled",
+			period:   NewPeriod(NewMonth(2024, 1), NewMonth(2025, 8)),
+			span:     0,
+			expected: []string{"2024-01/2025-08"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actual []string
+			for _, w := range tt.period.Windows(tt.span) {
+				actual = append(actual, w.String())
+			}
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestPV_Get_maxRange(t *testing.T) {
+	setNow(t, 2025, 8, 20)
+
+	var (
+		mu        sync.Mutex
+		requested []string
+	)
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv",
+		func(req *http.Request) (*http.Response, error) {
+			from := req.URL.Query().Get("from")
+			to := req.URL.Query().Get("to")
+			mu.Lock()
+			requested = append(requested, from+"/"+to)
+			mu.Unlock()
+
+			return httpmock.NewJsonResponse(http.StatusOK, &PVGetResponse{
+				PV:   &PVGetResult{Total: 100},
+				Meta: &PVGetMetadata{ProjectID: "project", From: from, To: to},
+			})
+		},
+	)
+
+	c := clienttest.NewClient(t, option.WithMaxRange(2), option.WithConcurrency(2))
+	pv := NewPV(c)
+
+	period := NewPeriod(NewMonth(2025, 4), NewMonth(2025, 8))
+	result, err := pv.Get(t.Context(), &PVGetInput{Period: &period})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &PVGetResponse{
+		PV: &PVGetResult{Total: 300},
+		Meta: &PVGetMetadata{
+			ProjectID: "project",
+			From:      "2025-04",
+			To:        "2025-08",
+			Windows: []Period{
+				NewPeriod(NewMonth(2025, 4), NewMonth(2025, 5)),
+				NewPeriod(NewMonth(2025, 6), NewMonth(2025, 7)),
+				NewPeriod(NewMonth(2025, 8), NewMonth(2025, 8)),
+			},
+		},
+	}, result)
+	assert.ElementsMatch(t, []string{"2025-04/2025-05", "2025-06/2025-07", "2025-08/2025-08"}, requested)
+
+	// The period is not split without the option.
+	requested = nil
+	result, err = pv.Get(t.Context(), &PVGetInput{Period: &period}, option.WithMaxRange(0))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 100, result.PV.Total)
+	assert.Nil(t, result.Meta.Windows)
+	assert.Equal(t, []string{"2025-04/2025-08"}, requested)
+}
+
+func TestDomains_ListAll(t *testing.T) {
+	setNow(t, 2025, 8, 20)
+
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv/domains",
+		func(req *http.Request) (*http.Response, error) {
+			from := req.URL.Query().Get("from")
+			to := req.URL.Query().Get("to")
+			meta := &DomainsListMetadata{ProjectID: "project", From: from, To: to}
+
+			var result []*DomainsListResult
+			switch from + "/" + to + "#" + req.URL.Query().Get(pager.Cursor) {
+			case "2025-05/2025-06#":
+				result = []*DomainsListResult{
+					{Domain: "a.example.com", Value: 100},
+				}
+				meta.Metadata = pager.Metadata{HasNext: true, NextCursor: lo.ToPtr("cursor1")}
+			case "2025-05/2025-06#cursor1":
+				result = []*DomainsListResult{
+					{Domain: "b.example.com", Value: 50},
+				}
+			case "2025-07/2025-08#":
+				result = []*DomainsListResult{
+					{Domain: "b.example.com", Value: 200},
+					{Domain: "c.example.com", Value: 10},
+				}
+			default:
+				return httpmock.NewStringResponse(http.StatusBadRequest, ""), nil
+			}
+
+			return httpmock.NewJsonResponse(http.StatusOK, pager.Page[*DomainsListResult, *DomainsListMetadata]{
+				Result: result,
+				Meta:   meta,
+			})
+		},
+	)
+
+	c := clienttest.NewClient(t, option.WithMaxRange(2))
+	domains := NewDomains(c)
+
+	period := NewPeriod(NewMonth(2025, 5), NewMonth(2025, 8))
+	result, err := domains.ListAll(t.Context(), &DomainsListInput{Period: &period})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &DomainsListAllResponse{
+		Domains: []*DomainsListResult{
+			{Domain: "b.example.com", Value: 250},
+			{Domain: "a.example.com", Value: 100},
+			{Domain: "c.example.com", Value: 10},
+		},
+		Meta: &DomainsListMetadata{
+			ProjectID: "project",
+			From:      "2025-05",
+			To:        "2025-08",
+			Windows: []Period{
+				NewPeriod(NewMonth(2025, 5), NewMonth(2025, 6)),
+				NewPeriod(NewMonth(2025, 7), NewMonth(2025, 8)),
+			},
+		},
+	}, result)
+
+	// The cursor of the whole period is not passed to the windows.
+	cursorInput := &DomainsListInput{Input: pager.Input{Cursor: lo.ToPtr("cursor1")}, Period: &period}
+	result, err = domains.ListAll(t.Context(), cursorInput)
+
+	if assert.NoError(t, err) {
+		assert.Len(t, result.Domains, 3)
+	}
+
+	// A window failing fails the whole list.
+	result, err = domains.ListAll(t.Context(), &DomainsListInput{Period: &period}, option.WithMaxRange(1))
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+func TestPV_Get_maxAge(t *testing.T) {
+	setNow(t, 2025, 8, 20)
+
+	var requested []string
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv",
+		func(req *http.Request) (*http.Response, error) {
+			from := req.URL.Query().Get("from")
+			to := req.URL.Query().Get("to")
+			requested = append(requested, from+"/"+to)
+
+			return httpmock.NewJsonResponse(http.StatusOK, &PVGetResponse{
+				PV:   &PVGetResult{Total: 100},
+				Meta: &PVGetMetadata{ProjectID: "project", From: from, To: to},
+			})
+		},
+	)
+
+	c := clienttest.NewClient(t, option.WithMaxAge(3))
+	pv := NewPV(c)
+
+	// The months before 2025-06 are clipped before the request.
+	period := NewPeriod(NewMonth(2025, 1), NewMonth(2025, 7))
+	result, err := pv.Get(t.Context(), &PVGetInput{Period: &period})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &PVGetResponse{
+		PV: &PVGetResult{Total: 100},
+		Meta: &PVGetMetadata{
+			ProjectID: "project",
+			From:      "2025-01",
+			To:        "2025-07",
+			Windows:   []Period{NewPeriod(NewMonth(2025, 6), NewMonth(2025, 7))},
+		},
+	}, result)
+	assert.Equal(t, []string{"2025-06/2025-07"}, requested)
+
+	// Periods within the maximum age are requested as is.
+	requested = nil
+	result, err = pv.Get(t.Context(), &PVGetInput{Period: lo.ToPtr(NewPeriod(NewMonth(2025, 6), NewMonth(2025, 8)))})
+
+	assert.NoError(t, err)
+	assert.Nil(t, result.Meta.Windows)
+	assert.Equal(t, []string{"2025-06/2025-08"}, requested)
+
+	// Periods entirely older than the maximum age are refused.
+	requested = nil
+	result, err = pv.Get(t.Context(), &PVGetInput{Period: lo.ToPtr(NewMonth(2025, 5).Period())})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrInvalidPeriod)
+	assert.ErrorContains(t, err, "older than 3 months")
+	assert.Empty(t, requested)
+}
+
+func TestDomains_ListAll_maxAge(t *testing.T) {
+	setNow(t, 2025, 8, 20)
+
+	var requested []string
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv/domains",
+		func(req *http.Request) (*http.Response, error) {
+			from := req.URL.Query().Get("from")
+			to := req.URL.Query().Get("to")
+			requested = append(requested, from+"/"+to)
+
+			return httpmock.NewJsonResponse(http.StatusOK, pager.Page[*DomainsListResult, *DomainsListMetadata]{
+				Result: []*DomainsListResult{{Domain: "a.example.com", Value: 100}},
+				Meta:   &DomainsListMetadata{ProjectID: "project", From: from, To: to},
+			})
+		},
+	)
+
+	c := clienttest.NewClient(t, option.WithMaxAge(2), option.WithMaxRange(1))
+	domains := NewDomains(c)
+
+	period := NewPeriod(NewMonth(2024, 12), NewMonth(2025, 8))
+	result, err := domains.ListAll(t.Context(), &DomainsListInput{Period: &period})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*DomainsListResult{{Domain: "a.example.com", Value: 200}}, result.Domains)
+	assert.Equal(t, []Period{NewMonth(2025, 7).Period(), NewMonth(2025, 8).Period()}, result.Meta.Windows)
+	assert.Equal(t, []string{"2025-07/2025-07", "2025-08/2025-08"}, requested)
+}
@@ -0,0 +1,252 @@
+// Package store provides a local file-based store of monthly page view statistics.
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/client"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/parallel"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/resource/stats"
+)
+
+var (
+	// ErrNotFound is returned when a month is not in the store.
+	ErrNotFound = errors.New("store: month not found")
+	// ErrProjectMismatch is returned when the page views are of another project than the store.
+	ErrProjectMismatch = errors.New("store: project mismatch")
+)
+
+// Month is the page views of a closed month.
+type Month struct {
+	Month     stats.Month                `json:"month"`
+	ProjectID string                     `json:"project_id"`
+	Total     int                        `json:"total"`
+	Domains   []*stats.DomainsListResult `json:"domains"`
+	FetchedAt time.Time                  `json:"fetched_at"`
+}
+
+// Store persists the page views of closed months of a project as a JSON file per month,
+// in a subdirectory of the directory named after the project ID.
+// Months are immutable once stored, so they are never fetched again.
+// The store works by month because the API accepts periods of whole months only.
+type Store struct {
+	dir       string
+	projectID string
+	client    *client.Client
+	stats     *stats.Stats
+}
+
+// Open opens the store of the project in the directory, creating it if it does not exist.
+// The client must access the project: page views of another project are refused with ErrProjectMismatch.
+func Open(dir string, projectID string, c *client.Client) (*Store, error) {
+	if projectID == "" || !filepath.IsLocal(projectID) || filepath.Base(projectID) != projectID {
+		return nil, fmt.Errorf("store: invalid project ID: %q", projectID)
+	}
+
+	dir = filepath.Join(dir, projectID)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &Store{
+		dir:       dir,
+		projectID: projectID,
+		client:    c,
+		stats:     stats.NewStats(c),
+	}, nil
+}
+
+// BackfillResult is the months fetched by a backfill.
+type BackfillResult struct {
+	// Fetched is the months fetched and stored, in chronological order.
+	Fetched []stats.Month
+	// Stored is the number of months already in the store.
+	Stored int
+	// Open is the months skipped because they are not closed yet.
+	Open []stats.Month
+}
+
+// Backfill fetches and stores the closed months of the period that are not in the store yet.
+// Months are fetched concurrently up to option.WithConcurrency.
+// The months fetched before an error are kept in the store.
+func (s *Store) Backfill(
+	ctx context.Context,
+	period stats.Period,
+	options ...option.Option,
+) (*BackfillResult, error) {
+	if err := period.Validate(); err != nil {
+		return nil, err
+	}
+
+	result := &BackfillResult{}
+	current := stats.CurrentMonth()
+	var missing []stats.Month
+	for _, p := range period.Split() {
+		month := p.From
+		switch {
+		case !month.Before(current):
+			result.Open = append(result.Open, month)
+		case s.has(month):
+			result.Stored++
+		default:
+			missing = append(missing, month)
+		}
+	}
+
+	var errs []error
+	fetchErrs := parallel.Each(ctx, len(missing), s.client.Options(options...).Concurrency, func(ctx context.Context, i int) error {
+		return s.fetch(ctx, missing[i], options...)
+	})
+	for i, err := range fetchErrs {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", missing[i], err))
+			continue
+		}
+		result.Fetched = append(result.Fetched, missing[i])
+	}
+	return result, errors.Join(errs...)
+}
+
+// fetch retrieves the page views of the month and stores them.
+func (s *Store) fetch(ctx context.Context, month stats.Month, options ...option.Option) error {
+	period := month.Period()
+
+	pv, err := s.stats.PV.Get(ctx, &stats.PVGetInput{Period: &period}, options...)
+	if err != nil {
+		return err
+	}
+	domains, err := s.stats.PV.Domains.ListAll(ctx, &stats.DomainsListInput{Period: &period}, options...)
+	if err != nil {
+		return err
+	}
+
+	m := &Month{
+		Month:     month,
+		Total:     pv.PV.Total,
+		Domains:   domains.Domains,
+		FetchedAt: time.Now(),
+	}
+	if pv.Meta != nil {
+		m.ProjectID = pv.Meta.ProjectID
+	}
+	if err := s.check(m); err != nil {
+		return err
+	}
+	return s.put(m)
+}
+
+// Month returns the stored page views of the month, or ErrNotFound.
+func (s *Store) Month(month stats.Month) (*Month, error) {
+	raw, err := os.ReadFile(s.path(month))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, month)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var m Month
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, fmt.Errorf("store: %s: %w", month, err)
+	}
+	if err := s.check(&m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// check returns ErrProjectMismatch if the month is not of the project of the store.
+func (s *Store) check(m *Month) error {
+	if m.ProjectID != s.projectID {
+		return fmt.Errorf("%w: %s: %q, want %q", ErrProjectMismatch, m.Month, m.ProjectID, s.projectID)
+	}
+	return nil
+}
+
+// QueryResult is the page views of a period summed from the store.
+type QueryResult struct {
+	Period stats.Period
+	Total  int
+	// Domains is the values summed by domain, ordered by value, descending.
+	Domains []*stats.DomainsListResult
+	// Months is the stored months in chronological order.
+	Months []*Month
+	// Missing is the months of the period not in the store.
+	Missing []stats.Month
+}
+
+// Query sums the stored page views of the months of the period without any request.
+// Months not in the store are reported in Missing.
+func (s *Store) Query(period stats.Period) (*QueryResult, error) {
+	result := &QueryResult{Period: period}
+	values := map[string]*stats.DomainsListResult{}
+	for _, p := range period.Split() {
+		m, err := s.Month(p.From)
+		if errors.Is(err, ErrNotFound) {
+			result.Missing = append(result.Missing, p.From)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		result.Months = append(result.Months, m)
+		result.Total += m.Total
+		for _, d := range m.Domains {
+			v, ok := values[d.Domain]
+			if !ok {
+				v = &stats.DomainsListResult{Domain: d.Domain}
+				values[d.Domain] = v
+				result.Domains = append(result.Domains, v)
+			}
+			v.Value += d.Value
+		}
+	}
+
+	slices.SortStableFunc(result.Domains, func(a, b *stats.DomainsListResult) int {
+		return b.Value - a.Value
+	})
+	return result, nil
+}
+
+func (s *Store) has(month stats.Month) bool {
+	_, err := os.Stat(s.path(month))
+	return err == nil
+}
+
+// put writes the month atomically so that a partially written file is never read.
+func (s *Store) put(m *Month) error {
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err := tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(m.Month))
+}
+
+func (s *Store) path(month stats.Month) string {
+	return filepath.Join(s.dir, month.String()+".json")
+}
@@ -0,0 +1,192 @@
+package store
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/pager"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/resource/stats"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupStatsMock(t *testing.T) *[]string {
+	t.Helper()
+
+	var (
+		mu        sync.Mutex
+		requested []string
+	)
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv",
+		func(req *http.Request) (*http.Response, error) {
+			from := req.URL.Query().Get("from")
+			if from == "2025-03" {
+				return httpmock.NewStringResponse(http.StatusInternalServerError, ""), nil
+			}
+			mu.Lock()
+			requested = append(requested, from)
+			mu.Unlock()
+
+			month, _ := stats.ParseMonth(from)
+			return httpmock.NewJsonResponse(http.StatusOK, &stats.PVGetResponse{
+				PV:   &stats.PVGetResult{Total: int(month.Month) * 100},
+				Meta: &stats.PVGetMetadata{ProjectID: "project", From: from, To: from},
+			})
+		},
+	)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv/domains",
+		func(req *http.Request) (*http.Response, error) {
+			month, _ := stats.ParseMonth(req.URL.Query().Get("from"))
+			return httpmock.NewJsonResponse(http.StatusOK, pager.Page[*stats.DomainsListResult, *stats.DomainsListMetadata]{
+				Result: []*stats.DomainsListResult{
+					{Domain: "a.example.com", Value: int(month.Month) * 60},
+					{Domain: "b.example.com", Value: int(month.Month) * 40},
+				},
+				Meta: &stats.DomainsListMetadata{ProjectID: "project"},
+			})
+		},
+	)
+	return &requested
+}
+
+func TestStore(t *testing.T) {
+	requested := setupStatsMock(t)
+
+	c := clienttest.NewClient(t, option.WithConcurrency(2))
+	dir := t.TempDir()
+	s, err := Open(dir, "project", c)
+	assert.NoError(t, err)
+
+	period := stats.NewPeriod(stats.NewMonth(2025, time.June), stats.NewMonth(2025, time.August))
+	result, err := s.Backfill(t.Context(), period)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &BackfillResult{
+		Fetched: []stats.Month{
+			stats.NewMonth(2025, time.June),
+			stats.NewMonth(2025, time.July),
+			stats.NewMonth(2025, time.August),
+		},
+	}, result)
+	assert.Len(t, *requested, 3)
+
+	// Closed months in the store are never fetched again.
+	*requested = nil
+	wider := stats.NewPeriod(stats.NewMonth(2025, time.May), stats.NewMonth(2025, time.August))
+	result, err = s.Backfill(t.Context(), wider)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []stats.Month{stats.NewMonth(2025, time.May)}, result.Fetched)
+	assert.Equal(t, 3, result.Stored)
+	assert.Equal(t, []string{"2025-05"}, *requested)
+
+	// Queries are answered from the store, even after reopening it.
+	httpmock.Reset()
+	s, err = Open(dir, "project", c)
+	assert.NoError(t, err)
+
+	query, err := s.Query(stats.NewPeriod(stats.NewMonth(2025, time.July), stats.NewMonth(2025, time.September)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1500, query.Total)
+	assert.Equal(t, []*stats.DomainsListResult{
+		{Domain: "a.example.com", Value: 900},
+		{Domain: "b.example.com", Value: 600},
+	}, query.Domains)
+	assert.Len(t, query.Months, 2)
+	assert.Equal(t, "project", query.Months[0].ProjectID)
+	assert.Equal(t, []stats.Month{stats.NewMonth(2025, time.September)}, query.Missing)
+	assert.Zero(t, httpmock.GetTotalCallCount())
+}
+
+func TestStore_Backfill_error(t *testing.T) {
+	setupStatsMock(t)
+
+	c := clienttest.NewClient(t)
+	s, err := Open(t.TempDir(), "project", c)
+	assert.NoError(t, err)
+
+	period := stats.NewPeriod(stats.NewMonth(2025, time.February), stats.NewMonth(2025, time.April))
+	result, err := s.Backfill(t.Context(), period)
+
+	assert.ErrorContains(t, err, "2025-03: ")
+	assert.Equal(t, []stats.Month{
+		stats.NewMonth(2025, time.February),
+		stats.NewMonth(2025, time.April),
+	}, result.Fetched)
+
+	_, err = s.Month(stats.NewMonth(2025, time.March))
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestStore_Backfill_open(t *testing.T) {
+	httpmock.Activate(t)
+
+	c := clienttest.NewClient(t)
+	dir := t.TempDir()
+	s, err := Open(dir, "project", c)
+	assert.NoError(t, err)
+
+	result, err := s.Backfill(t.Context(), stats.ThisMonth())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []stats.Month{stats.CurrentMonth()}, result.Open)
+	assert.Empty(t, result.Fetched)
+	assert.Zero(t, httpmock.GetTotalCallCount())
+
+	entries, err := os.ReadDir(filepath.Join(dir, "project"))
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestStore_Month_corrupted(t *testing.T) {
+	c := clienttest.NewClient(t)
+	dir := t.TempDir()
+	s, err := Open(dir, "project", c)
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "project", "2025-08.json"), []byte("{"), 0o600))
+
+	_, err = s.Month(stats.NewMonth(2025, time.August))
+	assert.ErrorContains(t, err, "store: 2025-08: ")
+}
+
+func TestStore_projectMismatch(t *testing.T) {
+	setupStatsMock(t)
+
+	c := clienttest.NewClient(t)
+	dir := t.TempDir()
+	s, err := Open(dir, "other", c)
+	assert.NoError(t, err)
+
+	// Page views of another project are not stored.
+	result, err := s.Backfill(t.Context(), stats.NewMonth(2025, time.July).Period())
+
+	assert.ErrorIs(t, err, ErrProjectMismatch)
+	assert.Empty(t, result.Fetched)
+
+	entries, err := os.ReadDir(filepath.Join(dir, "other"))
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// Months of another project are not read.
+	raw := []byte(`{"month":"2025-07","project_id":"project","total":700}`)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "other", "2025-07.json"), raw, 0o600))
+
+	_, err = s.Month(stats.NewMonth(2025, time.July))
+	assert.ErrorIs(t, err, ErrProjectMismatch)
+
+	_, err = Open(dir, "../project", c)
+	assert.Error(t, err)
+}
@@ -0,0 +1,192 @@
+package stats
+
+import (
+	"cmp"
+	"container/heap"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/parallel"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+)
+
+// OtherDomain is the domain name of the bucket grouping the domains outside the top.
+const OtherDomain = "other"
+
+// TopDomainsResult is the domains with the most page views in a period.
+type TopDomainsResult struct {
+	ProjectID string
+	// Total is the page views of the project in the period.
+	Total int
+	// Domains is ordered by value, descending.
+	Domains []*DomainShare
+	// Other groups the domains outside the top, or is nil if there are none.
+	Other *DomainShare
+	// OtherCount is the number of domains grouped into Other.
+	OtherCount int
+	// Windows is the periods queried when the period is split by option.WithMaxRange
+	// or clipped by option.WithMaxAge.
+	Windows []Period
+}
+
+// DomainShare is page views of a domain and its share of the project total.
+type DomainShare struct {
+	Domain string
+	Value  int
+	// Share is Value relative to the project total, from 0 to 1.
+	// It is 0 if the total is 0.
+	Share float64
+}
+
+// TopDomains walks all pages of the domains and returns the top n by value
+// with their shares of the project total for the same period.
+// The period is split and clipped as Domains.ListAll does. Only n domains are kept in memory
+// when the period is queried at once, and the rest are summed into the other bucket.
+// When it is split into windows, the values are summed by domain first
+// because a domain appears in every window it has page views in.
+func (p *PV) TopDomains(
+	ctx context.Context,
+	input *DomainsListInput,
+	n int,
+	options ...option.Option,
+) (*TopDomainsResult, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	var in DomainsListInput
+	if input != nil {
+		in = *input
+	}
+	ws, err := windows(in.Period, p.client.Options(options...))
+	if err != nil {
+		return nil, err
+	}
+	inputs := windowInputs(&in, ws)
+
+	var (
+		total      *PVGetResponse
+		top        = &topHeap{}
+		otherValue int
+		otherCount int
+		mu         sync.Mutex
+		sums       = map[string]int{}
+	)
+	push := func(r *DomainsListResult) {
+		var evicted *DomainsListResult
+		switch {
+		case n <= 0:
+			evicted = r
+		case top.Len() < n:
+			heap.Push(top, r)
+		case compareDomainsListResult(r, (*top)[0]) > 0:
+			evicted = (*top)[0]
+			(*top)[0] = r
+			heap.Fix(top, 0)
+		default:
+			evicted = r
+		}
+		if evicted != nil {
+			otherValue += evicted.Value
+			otherCount++
+		}
+	}
+
+	err = parallel.ForEach(ctx, len(inputs)+1, p.client.Options(options...).Concurrency, func(ctx context.Context, i int) error {
+		if i == 0 {
+			result, err := p.Get(ctx, &PVGetInput{From: in.From, To: in.To, Period: in.Period}, options...)
+			total = result
+			return err
+		}
+
+		pages := p.Domains.List(inputs[i-1], options...)
+		for pages.HasNextPage() {
+			page, err := pages.GetNextPage(ctx)
+			if err != nil {
+				return err
+			}
+
+			if len(inputs) == 1 {
+				for _, r := range page.Result {
+					push(r)
+				}
+				continue
+			}
+			mu.Lock()
+			for _, r := range page.Result {
+				sums[r.Domain] += r.Value
+			}
+			mu.Unlock()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for domain, value := range sums {
+		push(&DomainsListResult{Domain: domain, Value: value})
+	}
+
+	result := &TopDomainsResult{
+		Total:      total.PV.Total,
+		OtherCount: otherCount,
+		Windows:    ws,
+	}
+	if total.Meta != nil {
+		result.ProjectID = total.Meta.ProjectID
+	}
+
+	domains := slices.SortedFunc(slices.Values(*top), func(a, b *DomainsListResult) int {
+		return compareDomainsListResult(b, a)
+	})
+	for _, r := range domains {
+		result.Domains = append(result.Domains, &DomainShare{
+			Domain: r.Domain,
+			Value:  r.Value,
+			Share:  share(r.Value, result.Total),
+		})
+	}
+	if otherCount > 0 {
+		result.Other = &DomainShare{
+			Domain: OtherDomain,
+			Value:  otherValue,
+			Share:  share(otherValue, result.Total),
+		}
+	}
+	return result, nil
+}
+
+func share(value, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(value) / float64(total)
+}
+
+// compareDomainsListResult orders results by value, and by domain in reverse for the same value,
+// so that the greater result ranks higher.
+func compareDomainsListResult(a, b *DomainsListResult) int {
+	return cmp.Or(
+		cmp.Compare(a.Value, b.Value),
+		cmp.Compare(b.Domain, a.Domain),
+	)
+}
+
+// topHeap is a min-heap of results whose root is the lowest ranked.
+type topHeap []*DomainsListResult
+
+func (h topHeap) Len() int { return len(h) }
+
+func (h topHeap) Less(i, j int) bool { return compareDomainsListResult(h[i], h[j]) < 0 }
+
+func (h topHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *topHeap) Push(x any) { *h = append(*h, x.(*DomainsListResult)) }
+
+func (h *topHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
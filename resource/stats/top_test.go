@@ -0,0 +1,177 @@
+package stats
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/internal/clienttest"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/option"
+	"github.com/morisawa-inc/morisawafonts-webfont-go/pager"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTopMock(t *testing.T) {
+	t.Helper()
+
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "2025-07", req.URL.Query().Get("from"))
+
+			return httpmock.NewJsonResponse(http.StatusOK, &PVGetResponse{
+				PV:   &PVGetResult{Total: 1000},
+				Meta: &PVGetMetadata{ProjectID: "project"},
+			})
+		},
+	)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv/domains",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "2025-07", req.URL.Query().Get("from"))
+
+			page := pager.Page[*DomainsListResult, *DomainsListMetadata]{
+				Meta: &DomainsListMetadata{ProjectID: "project"},
+			}
+			switch req.URL.Query().Get(pager.Cursor) {
+			case "":
+				page.Result = []*DomainsListResult{
+					{Domain: "a.example.com", Value: 50},
+					{Domain: "b.example.com", Value: 400},
+					{Domain: "c.example.com", Value: 100},
+				}
+				page.Meta.Metadata = pager.Metadata{HasNext: true, NextCursor: lo.ToPtr("cursor1")}
+			case "cursor1":
+				page.Result = []*DomainsListResult{
+					{Domain: "d.example.com", Value: 300},
+					{Domain: "e.example.com", Value: 100},
+					{Domain: "f.example.com", Value: 50},
+				}
+			}
+			return httpmock.NewJsonResponse(http.StatusOK, page)
+		},
+	)
+}
+
+func TestPV_TopDomains(t *testing.T) {
+	setupTopMock(t)
+
+	c := clienttest.NewClient(t)
+	pv := NewPV(c)
+
+	result, err := pv.TopDomains(t.Context(), &DomainsListInput{From: lo.ToPtr("2025-07"), To: lo.ToPtr("2025-07")}, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &TopDomainsResult{
+		ProjectID: "project",
+		Total:     1000,
+		Domains: []*DomainShare{
+			{Domain: "b.example.com", Value: 400, Share: 0.4},
+			{Domain: "d.example.com", Value: 300, Share: 0.3},
+			{Domain: "c.example.com", Value: 100, Share: 0.1},
+		},
+		Other:      &DomainShare{Domain: OtherDomain, Value: 200, Share: 0.2},
+		OtherCount: 3,
+	}, result)
+}
+
+func TestPV_TopDomains_all(t *testing.T) {
+	setupTopMock(t)
+
+	c := clienttest.NewClient(t)
+	pv := NewPV(c)
+
+	result, err := pv.TopDomains(t.Context(), &DomainsListInput{Period: lo.ToPtr(NewMonth(2025, 7).Period())}, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Domains, 6)
+	assert.Equal(t, "f.example.com", result.Domains[5].Domain)
+	assert.Nil(t, result.Other)
+	assert.Zero(t, result.OtherCount)
+}
+
+func TestPV_TopDomains_zero(t *testing.T) {
+	setupTopMock(t)
+
+	c := clienttest.NewClient(t)
+	pv := NewPV(c)
+
+	result, err := pv.TopDomains(t.Context(), &DomainsListInput{Period: lo.ToPtr(NewMonth(2025, 7).Period())}, 0)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Domains)
+	assert.Equal(t, &DomainShare{Domain: OtherDomain, Value: 1000, Share: 1}, result.Other)
+	assert.Equal(t, 6, result.OtherCount)
+}
+
+func TestPV_TopDomains_maxRange(t *testing.T) {
+	setNow(t, 2025, 8, 20)
+
+	httpmock.Activate(t)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, &PVGetResponse{
+			PV:   &PVGetResult{Total: 500},
+			Meta: &PVGetMetadata{ProjectID: "project"},
+		}),
+	)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://api.morisawafonts.com/webfont/v1/stats/pv/domains",
+		func(req *http.Request) (*http.Response, error) {
+			page := pager.Page[*DomainsListResult, *DomainsListMetadata]{
+				Meta: &DomainsListMetadata{ProjectID: "project"},
+			}
+			if req.URL.Query().Has(pager.Cursor) {
+				return httpmock.NewStringResponse(http.StatusBadRequest, ""), nil
+			}
+			switch req.URL.Query().Get("from") {
+			case "2025-06":
+				page.Result = []*DomainsListResult{
+					{Domain: "a.example.com", Value: 300},
+					{Domain: "b.example.com", Value: 200},
+				}
+			case "2025-07":
+				page.Result = []*DomainsListResult{
+					{Domain: "c.example.com", Value: 320},
+					{Domain: "b.example.com", Value: 150},
+				}
+			default:
+				return httpmock.NewStringResponse(http.StatusBadRequest, ""), nil
+			}
+			return httpmock.NewJsonResponse(http.StatusOK, page)
+		},
+	)
+
+	c := clienttest.NewClient(t, option.WithMaxRange(1))
+	pv := NewPV(c)
+
+	period := NewPeriod(NewMonth(2025, 6), NewMonth(2025, 7))
+	result, err := pv.TopDomains(t.Context(), &DomainsListInput{Period: &period}, 1)
+
+	// b.example.com ranks first only when its values are summed across the windows.
+	assert.NoError(t, err)
+	assert.Equal(t, &TopDomainsResult{
+		ProjectID: "project",
+		Total:     1000,
+		Domains: []*DomainShare{
+			{Domain: "b.example.com", Value: 350, Share: 0.35},
+		},
+		Other:      &DomainShare{Domain: OtherDomain, Value: 620, Share: 0.62},
+		OtherCount: 2,
+		Windows:    []Period{NewMonth(2025, 6).Period(), NewMonth(2025, 7).Period()},
+	}, result)
+
+	// The cursor of the whole period is not passed to the windows.
+	cursorInput := &DomainsListInput{Input: pager.Input{Cursor: lo.ToPtr("cursor1")}, Period: &period}
+	result, err = pv.TopDomains(t.Context(), cursorInput, 1)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, 350, result.Domains[0].Value)
+	}
+}
@@ -10,6 +10,9 @@ import (
 type PVGetInput struct {
 	From *string
 	To   *string
+
+	// Period takes precedence over From and To if set.
+	Period *Period
 }
 
 func (i *PVGetInput) Values() url.Values {
@@ -24,9 +27,18 @@ func (i *PVGetInput) Values() url.Values {
 	if i.To != nil {
 		values.Set("to", *i.To)
 	}
+	setPeriod(values, i.Period)
 	return values
 }
 
+// Validate returns a *PeriodError if the period is not accepted by the API.
+func (i *PVGetInput) Validate() error {
+	if i == nil || i.Period == nil {
+		return nil
+	}
+	return i.Period.Validate()
+}
+
 type PVGetResponse struct {
 	PV   *PVGetResult   `json:"pv"`
 	Meta *PVGetMetadata `json:"meta"`
@@ -40,6 +52,15 @@ type PVGetMetadata struct {
 	ProjectID string `json:"project_id"`
 	From      string `json:"from"`
 	To        string `json:"to"`
+
+	// Windows is the periods queried when the period is split by option.WithMaxRange
+	// or clipped by option.WithMaxAge.
+	Windows []Period `json:"-"`
+}
+
+// Period parses From and To.
+func (m *PVGetMetadata) Period() (Period, error) {
+	return parsePeriod(m.From, m.To)
 }
 
 type DomainsListInput struct {
@@ -48,6 +69,9 @@ type DomainsListInput struct {
 	From   *string
 	To     *string
 	Domain *string
+
+	// Period takes precedence over From and To if set.
+	Period *Period
 }
 
 func (i *DomainsListInput) Values() url.Values {
@@ -65,10 +89,19 @@ func (i *DomainsListInput) Values() url.Values {
 	if i.Domain != nil {
 		values.Set("domain", *i.Domain)
 	}
+	setPeriod(values, i.Period)
 
 	return lo.Assign(values, i.Input.Values())
 }
 
+// Validate returns a *PeriodError if the period is not accepted by the API.
+func (i *DomainsListInput) Validate() error {
+	if i == nil || i.Period == nil {
+		return nil
+	}
+	return i.Period.Validate()
+}
+
 type DomainsListResult struct {
 	Domain string `json:"domain"`
 	Value  int    `json:"value"`
@@ -80,4 +113,21 @@ type DomainsListMetadata struct {
 	ProjectID string `json:"project_id"`
 	From      string `json:"from"`
 	To        string `json:"to"`
+
+	// Windows is the periods queried when the period is split by option.WithMaxRange
+	// or clipped by option.WithMaxAge.
+	Windows []Period `json:"-"`
+}
+
+// Period parses From and To.
+func (m *DomainsListMetadata) Period() (Period, error) {
+	return parsePeriod(m.From, m.To)
+}
+
+func setPeriod(values url.Values, period *Period) {
+	if period == nil {
+		return
+	}
+	values.Set("from", period.From.String())
+	values.Set("to", period.To.String())
 }